@@ -15,5 +15,5 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	lambda.Start(container.GetHandler())
+	lambda.Start(container.GetEventHandler())
 }
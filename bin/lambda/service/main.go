@@ -14,7 +14,7 @@ func main() {
 	defer container.Close("")
 	opnErr := container.Run()
 	if opnErr == nil {
-		lambda.Start(container.GetHandler())
+		lambda.Start(container.GetEventHandler())
 	}
 
 }
@@ -1,6 +1,7 @@
 package build
 
 import (
+	awsconn "github.com/pip-services3-go/pip-services3-aws-go/connect"
 	awscount "github.com/pip-services3-go/pip-services3-aws-go/count"
 	awslog "github.com/pip-services3-go/pip-services3-aws-go/log"
 	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
@@ -12,6 +13,8 @@ import (
  *
  See CloudWatchLogger
  See CloudWatchCounters
+ See AwsSessionProvider
+ See S3ClaimCheck
 */
 type DefaultAwsFactory struct {
 	cbuild.Factory
@@ -19,6 +22,8 @@ type DefaultAwsFactory struct {
 	Descriptor                   *cref.Descriptor
 	CloudWatchLoggerDescriptor   *cref.Descriptor
 	CloudWatchCountersDescriptor *cref.Descriptor
+	AwsSessionProviderDescriptor *cref.Descriptor
+	S3ClaimCheckDescriptor       *cref.Descriptor
 }
 
 // NewDefaultAwsFactory method are create a new instance of the factory.
@@ -29,9 +34,13 @@ func NewDefaultAwsFactory() *DefaultAwsFactory {
 		Descriptor:                   cref.NewDescriptor("pip-services", "factory", "aws", "default", "1.0"),
 		CloudWatchLoggerDescriptor:   cref.NewDescriptor("pip-services", "logger", "cloudwatch", "*", "1.0"),
 		CloudWatchCountersDescriptor: cref.NewDescriptor("pip-services", "counters", "cloudwatch", "*", "1.0"),
+		AwsSessionProviderDescriptor: cref.NewDescriptor("pip-services", "session-provider", "aws", "*", "1.0"),
+		S3ClaimCheckDescriptor:       cref.NewDescriptor("pip-services", "claim-check", "s3", "*", "1.0"),
 	}
 
 	c.RegisterType(c.CloudWatchLoggerDescriptor, awslog.NewCloudWatchLogger)
 	c.RegisterType(c.CloudWatchCountersDescriptor, awscount.NewCloudWatchCounters)
+	c.RegisterType(c.AwsSessionProviderDescriptor, awsconn.NewAwsSessionProvider)
+	c.RegisterType(c.S3ClaimCheckDescriptor, awsconn.NewS3ClaimCheck)
 	return c
 }
@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"context"
+)
+
+// Name of HTTP header that carries AWS X-Ray trace id
+const TraceHeader = "X-Amzn-Trace-Id"
+
+// Key used by AWS Lambda Go runtime to store trace id in the invocation context
+const lambdaTraceIdKey = "x-amzn-trace-id"
+
+type callContextKey string
+
+const (
+	correlationIdContextKey callContextKey = "correlation_id"
+	traceIdContextKey       callContextKey = "trace_id"
+)
+
+// ContextWithCorrelationId returns a copy of the context that carries the correlation id.
+//   - ctx               a parent context.
+//   - correlationId     transaction id to trace execution through call chain.
+// Returns the context with the correlation id.
+func ContextWithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	return context.WithValue(ctx, correlationIdContextKey, correlationId)
+}
+
+// CorrelationIdFromContext gets the correlation id stored in the context.
+//   - ctx    a context.
+// Returns the correlation id or empty string if it is not set.
+func CorrelationIdFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	correlationId, _ := ctx.Value(correlationIdContextKey).(string)
+	return correlationId
+}
+
+// ContextWithTraceId returns a copy of the context that carries the trace id,
+// like "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1".
+//   - ctx        a parent context.
+//   - traceId    AWS X-Ray trace id.
+// Returns the context with the trace id.
+func ContextWithTraceId(ctx context.Context, traceId string) context.Context {
+	return context.WithValue(ctx, traceIdContextKey, traceId)
+}
+
+// TraceIdFromContext gets the trace id stored in the context.
+// When lambda function makes calls, the trace id set by AWS Lambda runtime is used.
+//   - ctx    a context.
+// Returns the trace id or empty string if it is not set.
+func TraceIdFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if traceId, ok := ctx.Value(traceIdContextKey).(string); ok && traceId != "" {
+		return traceId
+	}
+	traceId, _ := ctx.Value(lambdaTraceIdKey).(string)
+	return traceId
+}
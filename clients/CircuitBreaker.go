@@ -0,0 +1,217 @@
+package clients
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+// States of the circuit breaker
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+/*
+Circuit breaker that stops calls to failing or throttled functions.
+
+The circuit opens after a number of consecutive failures and calls fail fast
+with CIRCUIT_OPEN error. After open timeout the circuit becomes half-open
+and lets a limited number of probe calls through. Successful probes close
+the circuit, while a failed probe opens it again.
+
+Only lost connections, timeouts, throttling and function failures are counted
+as failures. Business errors like BadRequest or NotFound do not open the circuit.
+Calls interrupted by the caller's context are released without being counted.
+
+### Configuration parameters ###
+
+ - options:
+     - circuit_breaker:
+         - enabled:                 (optional) true to enable the circuit breaker (default: false)
+         - failure_threshold:       (optional) number of consecutive failures to open the circuit (default: 5)
+         - open_timeout:            (optional) time in milliseconds the circuit stays open (default: 30 sec)
+         - half_open_probes:        (optional) number of successful probes to close the circuit (default: 1)
+*/
+type CircuitBreaker struct {
+	// Enables the circuit breaker
+	Enabled bool
+	// Number of consecutive failures to open the circuit
+	FailureThreshold int
+	// Time in milliseconds the circuit stays open
+	OpenTimeout int
+	// Number of successful probes to close the circuit
+	HalfOpenProbes int
+	// Callback that is called when the circuit changes its state.
+	// It is called under the lock and shall not call the circuit breaker.
+	OnStateChange func(correlationId string, from string, to string)
+
+	lock      sync.Mutex
+	state     string
+	failures  int
+	probes    int
+	successes int
+	openedAt  time.Time
+}
+
+// NewCircuitBreaker creates a new instance of the circuit breaker with default parameters.
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: 5,
+		OpenTimeout:      30000,
+		HalfOpenProbes:   1,
+		state:            CircuitClosed,
+	}
+}
+
+// Configures component by passing configuration parameters.
+//   - config    configuration parameters to be set.
+func (c *CircuitBreaker) Configure(config *cconf.ConfigParams) {
+	c.Enabled = config.GetAsBooleanWithDefault("options.circuit_breaker.enabled", c.Enabled)
+	c.FailureThreshold = config.GetAsIntegerWithDefault("options.circuit_breaker.failure_threshold", c.FailureThreshold)
+	c.OpenTimeout = config.GetAsIntegerWithDefault("options.circuit_breaker.open_timeout", c.OpenTimeout)
+	c.HalfOpenProbes = config.GetAsIntegerWithDefault("options.circuit_breaker.half_open_probes", c.HalfOpenProbes)
+}
+
+// Gets the current state of the circuit: "closed", "open" or "half_open".
+func (c *CircuitBreaker) GetState() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.state
+}
+
+// Checks if a call is allowed. Open circuit turns into half-open after open timeout.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns true when the call is admitted as a half-open probe,
+// and InvalidStateError with CIRCUIT_OPEN code when the call is not allowed.
+func (c *CircuitBreaker) Allow(correlationId string) (bool, error) {
+	if !c.Enabled {
+		return false, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= time.Duration(c.OpenTimeout)*time.Millisecond {
+		c.changeState(correlationId, CircuitHalfOpen)
+	}
+
+	switch c.state {
+	case CircuitOpen:
+		return false, c.newOpenError(correlationId)
+	case CircuitHalfOpen:
+		// Let through only a limited number of concurrent probes
+		if c.probes >= c.HalfOpenProbes {
+			return false, c.newOpenError(correlationId)
+		}
+		c.probes++
+		return true, nil
+	}
+	return false, nil
+}
+
+// Records result of the allowed call and changes the state of the circuit.
+// Calls interrupted by cancellation or deadline of the caller's context shall be released instead.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - probe             true if the call was admitted as a half-open probe.
+//   - err               an error returned by the call or nil for success.
+func (c *CircuitBreaker) Record(correlationId string, probe bool, err error) {
+	if !c.Enabled {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	failed := c.isFailure(err)
+
+	switch c.state {
+	case CircuitClosed:
+		if !failed {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= c.FailureThreshold {
+			c.changeState(correlationId, CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		// Results of calls admitted before the circuit opened are not probes
+		if !probe {
+			return
+		}
+		if c.probes > 0 {
+			c.probes--
+		}
+		if failed {
+			c.changeState(correlationId, CircuitOpen)
+			return
+		}
+		c.successes++
+		if c.successes >= c.HalfOpenProbes {
+			c.changeState(correlationId, CircuitClosed)
+		}
+	}
+}
+
+// Releases the allowed call without recording its result,
+// i.e. when the call was canceled by the caller.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - probe             true if the call was admitted as a half-open probe.
+func (c *CircuitBreaker) Release(correlationId string, probe bool) {
+	if !c.Enabled || !probe {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+func (c *CircuitBreaker) isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	appErr, ok := err.(*cerr.ApplicationError)
+	if !ok {
+		return true
+	}
+	switch appErr.Category {
+	case cerr.NoResponse, cerr.FailedInvocation, cerr.Internal, cerr.Unknown:
+		return true
+	}
+	return false
+}
+
+func (c *CircuitBreaker) changeState(correlationId string, state string) {
+	from := c.state
+	c.state = state
+	c.failures = 0
+	c.probes = 0
+	c.successes = 0
+	if state == CircuitOpen {
+		c.openedAt = time.Now()
+	}
+
+	if c.OnStateChange != nil {
+		c.OnStateChange(correlationId, from, state)
+	}
+}
+
+func (c *CircuitBreaker) newOpenError(correlationId string) error {
+	return cerr.NewInvalidStateError(
+		correlationId,
+		"CIRCUIT_OPEN",
+		"Circuit breaker is open, calls are temporarily rejected").
+		WithStatus(http.StatusServiceUnavailable).
+		WithDetails("state", c.state).
+		WithDetails("retry_after", c.openedAt.Add(time.Duration(c.OpenTimeout)*time.Millisecond).UTC())
+}
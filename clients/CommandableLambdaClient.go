@@ -1,6 +1,7 @@
 package clients
 
 import (
+	"context"
 	"reflect"
 
 	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
@@ -93,3 +94,34 @@ func (c *CommandableLambdaClient) CallCommand(prototype reflect.Type, cmd string
 	return callRes, callErr
 
 }
+
+// Calls a remote action in AWS Lambda function that honours
+// deadline and cancellation of the context.
+//   - ctx               a context to cancel the call.
+//   - prototype reflect.Type type for convert result. Set nil for return raw []byte
+//   - cmd               an action name
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - params            command parameters.
+//   - Return           result or error.
+func (c *CommandableLambdaClient) CallCommandWithContext(ctx context.Context, prototype reflect.Type, cmd string,
+	correlationId string, params *cdata.AnyValueMap) (result interface{}, err error) {
+	if correlationId == "" {
+		correlationId = CorrelationIdFromContext(ctx)
+	}
+	timing := c.Instrument(correlationId, c.name+"."+cmd)
+	callRes, callErr := c.CallWithContext(ctx, prototype, cmd, correlationId, params.Value())
+	timing.EndTiming()
+	return callRes, callErr
+}
+
+// Calls remote actions in AWS Lambda function in parallel with concurrency
+// limited by "options.max_concurrency". Results and errors are returned in the order of the calls.
+//   - ctx               a context to cancel the calls.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - calls             a list of calls to execute.
+//   - failFast          true to stop on the first error, false to collect all results.
+// Returns results of the calls and the first error in fail-fast mode.
+func (c *CommandableLambdaClient) CallMany(ctx context.Context, correlationId string, calls []*LambdaCall,
+	failFast bool) (results []*LambdaCallResult, err error) {
+	return c.callMany(ctx, c.name+".", correlationId, calls, failFast)
+}
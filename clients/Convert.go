@@ -1,8 +1,18 @@
 package clients
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
 	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
 )
 
 //ConvertComandResult method helps get correct result from JSON by prototype
@@ -29,3 +39,161 @@ func ConvertComandResult(comRes interface{}, prototype reflect.Type) (convRes in
 
 	return convRes, nil
 }
+
+//ConvertErrorEnvelope method restores ApplicationError from serialized error envelope
+//returned by lambda function, like {"error": {"code": "...", "category": "...", ...}}
+//Parameters:
+//   - payload []byte  input JSON string
+// Returns: err *ApplicationError or nil when the payload is not an error envelope
+func ConvertErrorEnvelope(payload []byte) *cerr.ApplicationError {
+	var envelope map[string]*cerr.ErrorDescription
+	if err := json.Unmarshal(payload, &envelope); err != nil || len(envelope) != 1 {
+		return nil
+	}
+
+	description := envelope["error"]
+	if description == nil || description.Code == "" || description.Category == "" {
+		return nil
+	}
+	return cerr.ApplicationErrorFactory.Create(description)
+}
+
+//ConvertFunctionError method restores ApplicationError from AWS Lambda function error payload,
+//like {"errorMessage": "...", "errorType": "..."}.
+//When error message contains serialized error envelope or error description, the original error is restored.
+//Unhandled errors raised by the runtime, like function timeouts, are converted to InvocationError.
+//Parameters:
+//   - correlationId string  (optional) transaction id to trace execution through call chain.
+//   - functionError string  function error type: "Handled" or "Unhandled"
+//   - payload []byte  input JSON string
+// Returns: err *ApplicationError
+func ConvertFunctionError(correlationId string, functionError string, payload []byte) *cerr.ApplicationError {
+	if err := ConvertErrorEnvelope(payload); err != nil {
+		return err
+	}
+
+	var functionErr struct {
+		ErrorMessage string   `json:"errorMessage"`
+		ErrorType    string   `json:"errorType"`
+		StackTrace   []string `json:"stackTrace"`
+	}
+	if err := json.Unmarshal(payload, &functionErr); err != nil {
+		return cerr.NewInvocationError(
+			correlationId,
+			"CALL_FAILED",
+			"Failed to invoke lambda function").
+			WithDetails("function_error", functionError).
+			WithCauseString(string(payload))
+	}
+
+	if err := ConvertErrorEnvelope([]byte(functionErr.ErrorMessage)); err != nil {
+		return err
+	}
+
+	var description cerr.ErrorDescription
+	if json.Unmarshal([]byte(functionErr.ErrorMessage), &description) == nil && description.Code != "" {
+		return cerr.ApplicationErrorFactory.Create(&description)
+	}
+
+	code := "CALL_FAILED"
+	status := http.StatusInternalServerError
+	if functionError == "Unhandled" {
+		code = "UNHANDLED_ERROR"
+		if strings.Contains(functionErr.ErrorMessage, "Task timed out") {
+			code = "FUNCTION_TIMEOUT"
+			status = http.StatusGatewayTimeout
+		}
+	}
+
+	err := cerr.NewInvocationError(
+		correlationId,
+		code,
+		functionErr.ErrorMessage).
+		WithStatus(status).
+		WithDetails("function_error", functionError)
+	if functionErr.ErrorType != "" {
+		err.WithDetails("error_type", functionErr.ErrorType)
+	}
+	return err
+}
+
+//ConvertInvokeError method converts error returned by AWS Lambda Invoke API into ApplicationError.
+//Throttling, timeouts, too large payloads, missing functions and access errors
+//are mapped to corresponding error types with the original error attached as cause.
+//Parameters:
+//   - correlationId string  (optional) transaction id to trace execution through call chain.
+//   - err error  an error returned by AWS SDK
+// Returns: err *ApplicationError
+func ConvertInvokeError(correlationId string, err error) *cerr.ApplicationError {
+	code := ""
+	if awsErr, ok := err.(awserr.Error); ok {
+		code = awsErr.Code()
+	} else if err == context.Canceled || err == context.DeadlineExceeded {
+		// Context was done before the call
+		err = awserr.New(request.CanceledErrorCode, "request context canceled", err)
+		code = request.CanceledErrorCode
+	}
+
+	switch code {
+	case lambda.ErrCodeTooManyRequestsException, lambda.ErrCodeEC2ThrottledException, "ThrottlingException":
+		return cerr.NewInvocationError(
+			correlationId,
+			"TOO_MANY_REQUESTS",
+			"Lambda function invocation was throttled").
+			WithStatus(http.StatusTooManyRequests).
+			WithCause(err)
+	case lambda.ErrCodeRequestTooLargeException:
+		return cerr.NewBadRequestError(
+			correlationId,
+			"PAYLOAD_TOO_LARGE",
+			"Lambda function request payload is too large").
+			WithStatus(http.StatusRequestEntityTooLarge).
+			WithCause(err)
+	case lambda.ErrCodeResourceNotFoundException:
+		return cerr.NewNotFoundError(
+			correlationId,
+			"FUNCTION_NOT_FOUND",
+			"Lambda function was not found").
+			WithCause(err)
+	case "AccessDeniedException", "UnrecognizedClientException", "InvalidSignatureException", "ExpiredTokenException":
+		return cerr.NewUnauthorizedError(
+			correlationId,
+			"ACCESS_DENIED",
+			"Access to lambda function was denied").
+			WithCause(err)
+	case request.CanceledErrorCode:
+		if errors.Is(err.(awserr.Error).OrigErr(), context.DeadlineExceeded) {
+			return cerr.NewConnectionError(
+				correlationId,
+				"CALL_TIMEOUT",
+				"Lambda function invocation exceeded the context deadline").
+				WithStatus(http.StatusGatewayTimeout).
+				WithCause(err)
+		}
+		return cerr.NewInvocationError(
+			correlationId,
+			"CALL_CANCELED",
+			"Lambda function invocation was canceled").
+			WithCause(err)
+	case request.ErrCodeRequestError, request.ErrCodeResponseTimeout:
+		if netErr, ok := err.(awserr.Error).OrigErr().(net.Error); code == request.ErrCodeResponseTimeout || (ok && netErr.Timeout()) {
+			return cerr.NewConnectionError(
+				correlationId,
+				"CALL_TIMEOUT",
+				"Lambda function invocation timed out").
+				WithStatus(http.StatusGatewayTimeout).
+				WithCause(err)
+		}
+		return cerr.NewConnectionError(
+			correlationId,
+			"CONNECTION_FAILED",
+			"Failed to connect to lambda service").
+			WithCause(err)
+	}
+
+	return cerr.NewInvocationError(
+		correlationId,
+		"CALL_FAILED",
+		"Failed to invoke lambda function").
+		WithCause(err)
+}
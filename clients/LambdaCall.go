@@ -0,0 +1,36 @@
+package clients
+
+import (
+	"reflect"
+)
+
+// LambdaCall describes a single call of AWS Lambda Function action
+// executed by LambdaClient.CallMany.
+type LambdaCall struct {
+	// Type to convert the result. Set nil to return raw []byte
+	Prototype reflect.Type
+	// An action name to be called
+	Cmd string
+	// Action parameters
+	Params map[string]interface{}
+}
+
+// NewLambdaCall creates a new call description.
+//   - prototype    type to convert the result. Set nil to return raw []byte
+//   - cmd          an action name to be called.
+//   - params       (optional) action parameters.
+func NewLambdaCall(prototype reflect.Type, cmd string, params map[string]interface{}) *LambdaCall {
+	return &LambdaCall{
+		Prototype: prototype,
+		Cmd:       cmd,
+		Params:    params,
+	}
+}
+
+// LambdaCallResult holds result or error of a single call executed by LambdaClient.CallMany.
+type LambdaCallResult struct {
+	// The call result
+	Result interface{}
+	// The call error or nil for success
+	Err error
+}
@@ -1,14 +1,16 @@
 package clients
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
 	"reflect"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
@@ -32,12 +34,42 @@ other parameters are passed to the action itself.
  - connections:
      - discovery_key:               (optional) a key to retrieve the connection from IDiscovery
      - region:                      (optional) AWS region
+     - qualifier:                   (optional) function version or alias, can also be set in the ARN
+     - endpoint:                    (optional) custom AWS service endpoint, i.e. LocalStack or LambdaEmulator
+     - s3_force_path_style:         (optional) true to use path-style addressing for S3 buckets (default: false)
+     - disable_ssl:                 (optional) true to disable SSL (default: false)
+     - ca_bundle:                   (optional) path to custom CA bundle file in PEM format
+     - sts_endpoint:                (optional) custom STS endpoint to assume the role
  - credentials:
      - store_key:                   (optional) a key to retrieve the credentials from ICredentialStore
-     - access_id:                   AWS access/client id
-     - access_key:                  AWS access/client id
+     - access_id:                   (optional) AWS access/client id
+     - access_key:                  (optional) AWS access/client key
+     - profile:                     (optional) named profile in shared AWS credentials files
+     - session_token:               (optional) session token for temporary credentials
+     - role_arn:                    (optional) ARN of the role to assume with STS
+     - external_id:                 (optional) external id to assume the role
+     - role_session_name:           (optional) session name of the assumed role
  - options:
      - connect_timeout:             (optional) connection timeout in milliseconds (default: 10 sec)
+     - max_retries:                 (optional) maximum number of retries for failed requests by AWS SDK (default: 3)
+     - max_concurrency:             (optional) maximum number of parallel calls made by CallMany (default: 10)
+     - retries:                     (optional) number of retries of failed calls (default: 3)
+     - retry_min_timeout:           (optional) minimum timeout between retries in milliseconds (default: 100)
+     - retry_max_timeout:           (optional) maximum timeout between retries in milliseconds (default: 10 sec)
+     - retry_jitter:                (optional) true to randomize timeouts between retries (default: true)
+     - retry_commands:              (optional) comma-separated list of idempotent commands to retry, "*" for all commands
+     - retry_one_way:               (optional) true to retry one-way calls (default: false)
+     - circuit_breaker:
+         - enabled:                 (optional) true to fail fast when the function keeps failing (default: false)
+         - failure_threshold:       (optional) number of consecutive failures to open the circuit (default: 5)
+         - open_timeout:            (optional) time in milliseconds the circuit stays open (default: 30 sec)
+         - half_open_probes:        (optional) number of successful probes to close the circuit (default: 1)
+     - claim_check:
+         - bucket:                  (optional) S3 bucket to pass large payloads, claim check is disabled when not set
+         - prefix:                  (optional) prefix of object keys (default: "claim-check/")
+         - threshold:               (optional) payload size in bytes to store in S3, payloads above invocation limit are always stored (default: 5 MB)
+         - delete_on_read:          (optional) true to delete objects after they were read (default: true)
+         - endpoint:                (optional) custom S3 endpoint, i.e. local S3 stand-in
 
 ### References ###
 
@@ -45,6 +77,8 @@ other parameters are passed to the action itself.
  - \*:counters:\*:\*:1.0          (optional) ICounters components to pass collected measurements
  - \*:discovery:\*:\*:1.0         (optional) IDiscovery services to resolve connection
  - \*:credential-store:\*:\*:1.0  (optional) Credential stores to resolve credentials
+ - \*:session-provider:aws:\*:1.0 (optional) Shared AwsSessionProvider to create AWS sessions
+ - \*:claim-check:s3:\*:1.0      (optional) Shared S3ClaimCheck to pass large payloads via S3
 
  See LambdaFunction
  See CommandableLambdaClient
@@ -84,27 +118,47 @@ type LambdaClient struct {
 	// The AWS connection parameters
 	Connection     *awscon.AwsConnectionParams
 	connectTimeout int
+	maxConcurrency int
 	// The dependencies resolver.
 	DependencyResolver *cref.DependencyResolver
 	// The connection resolver.
 	ConnectionResolver *awscon.AwsConnectionResolver
+	// The AWS session provider. It is replaced by a shared provider found in references.
+	SessionProvider    *awscon.AwsSessionProvider
+	ownSessionProvider bool
 	// The logger.
 	Logger *clog.CompositeLogger
 	//The performance counters.
 	Counters *ccount.CompositeCounters
 	// The tracer.
 	Tracer *ctrace.CompositeTracer
+	// The retry policy for failed calls.
+	RetryPolicy *RetryPolicy
+	// The circuit breaker to stop calls to failing function.
+	CircuitBreaker *CircuitBreaker
+	// The claim check to pass large payloads via S3.
+	ClaimCheck    *awscon.S3ClaimCheck
+	ownClaimCheck bool
 }
 
 func NewLambdaClient() *LambdaClient {
 	c := &LambdaClient{
 		Opened:             false,
 		connectTimeout:     10000,
+		maxConcurrency:     10,
 		DependencyResolver: cref.NewDependencyResolver(),
 		ConnectionResolver: awscon.NewAwsConnectionResolver(),
+		SessionProvider:    awscon.NewAwsSessionProvider(),
+		ownSessionProvider: true,
 		Logger:             clog.NewCompositeLogger(),
 		Counters:           ccount.NewCompositeCounters(),
+		RetryPolicy:        NewRetryPolicy(),
+		CircuitBreaker:     NewCircuitBreaker(),
+		ClaimCheck:         awscon.NewS3ClaimCheck(),
+		ownClaimCheck:      true,
 	}
+	c.ClaimCheck.SetSessionProvider(c.SessionProvider)
+	c.CircuitBreaker.OnStateChange = c.onCircuitStateChange
 	return c
 }
 
@@ -114,6 +168,13 @@ func (c *LambdaClient) Configure(config *cconf.ConfigParams) {
 	c.ConnectionResolver.Configure(config)
 	c.DependencyResolver.Configure(config)
 	c.connectTimeout = config.GetAsIntegerWithDefault("options.connect_timeout", c.connectTimeout)
+	c.maxConcurrency = config.GetAsIntegerWithDefault("options.max_concurrency", c.maxConcurrency)
+	c.RetryPolicy.Configure(config)
+	c.CircuitBreaker.Configure(config)
+	c.ClaimCheck.Configure(config.GetSection("options.claim_check"))
+	c.SessionProvider.Configure(config.SetDefaults(cconf.NewConfigParamsFromTuples(
+		"options.connect_timeout", c.connectTimeout,
+	)))
 }
 
 /*
@@ -126,6 +187,26 @@ func (c *LambdaClient) SetReferences(references cref.IReferences) {
 	c.Counters.SetReferences(references)
 	c.ConnectionResolver.SetReferences(references)
 	c.DependencyResolver.SetReferences(references)
+	c.SessionProvider.SetReferences(references)
+
+	// Use shared session provider when it is available
+	ref := references.GetOneOptional(
+		cref.NewDescriptor("*", "session-provider", "aws", "*", "1.0"))
+	if sessionProvider, ok := ref.(*awscon.AwsSessionProvider); ok {
+		c.SessionProvider = sessionProvider
+		c.ownSessionProvider = false
+	}
+
+	// Use shared claim check when it is available
+	ref = references.GetOneOptional(
+		cref.NewDescriptor("*", "claim-check", "s3", "*", "1.0"))
+	if claimCheck, ok := ref.(*awscon.S3ClaimCheck); ok {
+		c.ClaimCheck = claimCheck
+		c.ownClaimCheck = false
+	} else {
+		c.ClaimCheck.SetReferences(references)
+		c.ClaimCheck.SetSessionProvider(c.SessionProvider)
+	}
 }
 
 // Adds instrumentation to log calls and measure call time.
@@ -139,6 +220,19 @@ func (c *LambdaClient) Instrument(correlationId string, name string) *ccount.Cou
 	return c.Counters.BeginTiming(name + ".exec_time")
 }
 
+func (c *LambdaClient) onCircuitStateChange(correlationId string, from string, to string) {
+	name := "lambda"
+	if c.Connection != nil {
+		name = c.Connection.GetArn()
+	}
+	if to == CircuitOpen {
+		c.Logger.Warn(correlationId, "Circuit breaker for %s changed from %s to %s", name, from, to)
+	} else {
+		c.Logger.Info(correlationId, "Circuit breaker for %s changed from %s to %s", name, from, to)
+	}
+	c.Counters.IncrementOne("circuit_breaker." + to)
+}
+
 //  Checks if the component is opened.
 //  Returns true if the component has been opened and false otherwise.
 func (c *LambdaClient) IsOpen() bool {
@@ -161,17 +255,36 @@ func (c *LambdaClient) Open(correlationId string) error {
 		defer wg.Done()
 		connection, err := c.ConnectionResolver.Resolve(correlationId)
 		c.Connection = connection
-		errGlobal = err
-
-		awsCred := credentials.NewStaticCredentials(c.Connection.GetAccessId(), c.Connection.GetAccessKey(), "")
-		sess := session.Must(session.NewSession(&aws.Config{
-			MaxRetries:  aws.Int(3),
-			Region:      aws.String(c.Connection.GetRegion()),
-			Credentials: awsCred,
-		}))
-		// Create new cloudwatch client.
-		c.Lambda = lambda.New(sess)
-		c.Lambda.Config.HTTPClient.Timeout = time.Duration((int64)(c.connectTimeout)) * time.Millisecond
+		if err != nil {
+			errGlobal = err
+			return
+		}
+
+		err = c.SessionProvider.Open(correlationId)
+		if err != nil {
+			errGlobal = err
+			return
+		}
+
+		// Get lambda client from the session provider.
+		client, err := c.SessionProvider.GetClient(correlationId, "lambda", func(sess *session.Session) interface{} {
+			return lambda.New(sess)
+		})
+		if err != nil {
+			errGlobal = err
+			return
+		}
+		c.Lambda = client.(*lambda.Lambda)
+
+		if c.ownClaimCheck {
+			err = c.ClaimCheck.Open(correlationId)
+			if err != nil {
+				errGlobal = err
+				return
+			}
+		}
+
+		c.Opened = true
 		c.Logger.Debug(correlationId, "Lambda client connected to %s", c.Connection.GetArn())
 
 	}()
@@ -190,10 +303,18 @@ func (c *LambdaClient) Close(correlationId string) error {
 	// Todo: close listening?
 	c.Opened = false
 	c.Lambda = nil
+	if c.ownClaimCheck {
+		c.ClaimCheck.Close(correlationId)
+	}
+	if c.ownSessionProvider {
+		return c.SessionProvider.Close(correlationId)
+	}
 	return nil
 }
 
 // Performs AWS Lambda Function invocation.
+// Errors returned by the function in error envelopes or function error payloads
+// are restored as ApplicationError with original code, category and details.
 // 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
 //   - invocationType    an invocation type: "RequestResponse" or "Event"
 //   - cmd               an action name to be called.
@@ -202,11 +323,62 @@ func (c *LambdaClient) Close(correlationId string) error {
 // Returns           result or error.
 
 func (c *LambdaClient) Invoke(prototype reflect.Type, invocationType string, cmd string, correlationId string, args map[string]interface{}) (result interface{}, err error) {
+	result, _, err = c.InvokeWithQualifier(prototype, invocationType, "", cmd, correlationId, args)
+	return result, err
+}
+
+// Gets unqualified function name and qualifier to invoke.
+// The qualifier is taken from the ARN or "connection.qualifier" unless it is overriden.
+func (c *LambdaClient) getFunctionName(qualifier string) (string, string) {
+	functionName := c.Connection.GetArn()
+	if arn, err := awscon.ParseAwsArn("", functionName); err == nil && arn.Qualifier != "" {
+		functionName = arn.Unqualified()
+	}
+	if qualifier == "" {
+		qualifier = c.Connection.GetQualifier()
+	}
+	return functionName, qualifier
+}
+
+// Performs AWS Lambda Function invocation of specific version or alias.
+// Errors returned by the function in error envelopes or function error payloads
+// are restored as ApplicationError with original code, category and details.
+// Calls of idempotent commands that failed with retryable errors are retried
+// according to the RetryPolicy. When the CircuitBreaker is open, calls fail fast.
+// 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
+//   - invocationType    an invocation type: "RequestResponse" or "Event"
+//   - qualifier         (optional) a function version or alias that overrides configured qualifier.
+//   - cmd               an action name to be called.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - args              action arguments
+// Returns           result, the function version that was executed or error.
+func (c *LambdaClient) InvokeWithQualifier(prototype reflect.Type, invocationType string, qualifier string,
+	cmd string, correlationId string, args map[string]interface{}) (result interface{}, executedVersion string, err error) {
+	return c.InvokeWithContext(context.Background(), prototype, invocationType, qualifier, cmd, correlationId, args)
+}
+
+// Performs AWS Lambda Function invocation that honours deadline and cancellation of the context.
+// When correlation id is not set it is taken from the context. AWS X-Ray trace id
+// from the context is passed to the function in X-Amzn-Trace-Id header.
+//   - ctx               a context to cancel the invocation.
+// 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
+//   - invocationType    an invocation type: "RequestResponse" or "Event"
+//   - qualifier         (optional) a function version or alias that overrides configured qualifier.
+//   - cmd               an action name to be called.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - args              action arguments
+// Returns           result, the function version that was executed or error.
+func (c *LambdaClient) InvokeWithContext(ctx context.Context, prototype reflect.Type, invocationType string, qualifier string,
+	cmd string, correlationId string, args map[string]interface{}) (result interface{}, executedVersion string, err error) {
+
+	if correlationId == "" {
+		correlationId = CorrelationIdFromContext(ctx)
+	}
 
 	if cmd == "" {
 		err = cerr.NewUnknownError("", "NO_COMMAND", "Missing cmd")
 		c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
-		return nil, err
+		return nil, "", err
 	}
 
 	//args = _.clone(args)
@@ -221,24 +393,125 @@ func (c *LambdaClient) Invoke(prototype reflect.Type, invocationType string, cmd
 
 	if jsonErr != nil {
 		c.Logger.Error(correlationId, jsonErr, "Failed to call %s", cmd)
-		return nil, jsonErr
+		return nil, "", jsonErr
 	}
 
+	oneWay := invocationType == "Event"
+	maxSize := awscon.LambdaMaxSyncPayloadSize
+	if oneWay {
+		maxSize = awscon.LambdaMaxAsyncPayloadSize
+	}
+
+	// Pass large requests via S3 or fail before the call
+	offload := c.ClaimCheck.NeedsOffload(len(payloads)) ||
+		(c.ClaimCheck.IsEnabled() && len(payloads) > maxSize)
+	if !offload && len(payloads) > maxSize {
+		err = cerr.NewBadRequestError(
+			correlationId,
+			"PAYLOAD_TOO_LARGE",
+			"Lambda function request payload is too large").
+			WithStatus(http.StatusRequestEntityTooLarge).
+			WithDetails("size", len(payloads)).
+			WithDetails("max_size", maxSize)
+		c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+		return nil, "", err
+	}
+	canRetry := c.RetryPolicy.CanRetry(cmd, oneWay)
+
+	for attempt := 0; ; attempt++ {
+		if ctx.Err() != nil {
+			err = ConvertInvokeError(correlationId, ctx.Err())
+			c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+			return nil, "", err
+		}
+
+		// The request is stored on every attempt, since the function deletes it on read
+		// and may fail after that
+		request := payloads
+		if offload {
+			request, err = c.writeClaimCheck(ctx, correlationId, cmd, args["correlation_id"], payloads)
+			if err != nil {
+				c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+				return nil, "", err
+			}
+		}
+
+		var probe bool
+		if probe, err = c.CircuitBreaker.Allow(correlationId); err != nil {
+			c.Logger.Warn(correlationId, "Rejected %s call: circuit breaker is open", cmd)
+			return nil, "", err
+		}
+
+		result, executedVersion, err = c.invokeOnce(ctx, prototype, invocationType, qualifier, cmd, correlationId, request)
+		if err != nil && ctx.Err() != nil {
+			// Calls interrupted by the caller do not indicate failures of the function
+			c.CircuitBreaker.Release(correlationId, probe)
+		} else {
+			c.CircuitBreaker.Record(correlationId, probe, err)
+		}
+		if err == nil || !canRetry || attempt >= c.RetryPolicy.Retries || !c.RetryPolicy.IsRetryable(err) {
+			return result, executedVersion, err
+		}
+
+		timeout := c.RetryPolicy.GetTimeout(attempt + 1)
+		c.Logger.Warn(correlationId, "Retrying %s call in %d ms, attempt %d of %d: %s",
+			cmd, timeout.Milliseconds(), attempt+1, c.RetryPolicy.Retries, err.Error())
+		c.Counters.IncrementOne(cmd + ".retries")
+
+		select {
+		case <-time.After(timeout):
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (c *LambdaClient) invokeOnce(ctx context.Context, prototype reflect.Type, invocationType string, qualifier string,
+	cmd string, correlationId string, payloads []byte) (result interface{}, executedVersion string, err error) {
+
+	functionName, qualifier := c.getFunctionName(qualifier)
 	params := &lambda.InvokeInput{
-		FunctionName:   aws.String(c.Connection.GetArn()),
+		FunctionName:   aws.String(functionName),
 		InvocationType: aws.String(invocationType),
 		LogType:        aws.String("None"),
 		Payload:        payloads,
 	}
+	if qualifier != "" {
+		params.Qualifier = aws.String(qualifier)
+	}
+
+	options := make([]request.Option, 0)
+	if traceId := TraceIdFromContext(ctx); traceId != "" {
+		options = append(options, request.WithSetRequestHeaders(map[string]string{TraceHeader: traceId}))
+	}
 
-	data, lambdaErr := c.Lambda.Invoke(params)
+	data, lambdaErr := c.Lambda.InvokeWithContext(ctx, params, options...)
 
 	if lambdaErr != nil {
+		err = ConvertInvokeError(correlationId, lambdaErr)
+		c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+		return nil, "", err
+	}
+
+	if data.ExecutedVersion != nil {
+		executedVersion = *data.ExecutedVersion
+		c.Logger.Trace(correlationId, "Executed %s on version %s", cmd, executedVersion)
+	}
+
+	if data.FunctionError != nil {
+		err = ConvertFunctionError(correlationId, *data.FunctionError, data.Payload)
+		c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+		return nil, executedVersion, err
+	}
+
+	if data.StatusCode != nil && *data.StatusCode >= http.StatusMultipleChoices {
 		err = cerr.NewInvocationError(
 			correlationId,
 			"CALL_FAILED",
-			"Failed to invoke lambda function").WithCause(err)
-		return nil, err
+			"Lambda function returned unexpected status "+strconv.FormatInt(*data.StatusCode, 10)).
+			WithStatus(int(*data.StatusCode)).
+			WithDetails("status_code", *data.StatusCode)
+		c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+		return nil, executedVersion, err
 	}
 
 	if data.Payload != nil && len(data.Payload) > 0 {
@@ -247,14 +520,62 @@ func (c *LambdaClient) Invoke(prototype reflect.Type, invocationType string, cmd
 		if err != nil {
 			unesccapedResult = (string)(data.Payload)
 		}
+		unesccapedResult, err = c.readClaimCheck(ctx, correlationId, unesccapedResult)
+		if err != nil {
+			c.Logger.Error(correlationId, err, "Failed to call %s", cmd)
+			return nil, executedVersion, err
+		}
+		if appErr := ConvertErrorEnvelope(([]byte)(unesccapedResult)); appErr != nil {
+			return nil, executedVersion, appErr
+		}
 		if prototype != nil {
-			return ConvertComandResult(([]byte)(unesccapedResult), prototype)
+			result, err = ConvertComandResult(([]byte)(unesccapedResult), prototype)
+			return result, executedVersion, err
 		}
-		return ([]byte)(unesccapedResult), nil
+		return ([]byte)(unesccapedResult), executedVersion, nil
 	}
 
-	return nil, nil
+	return nil, executedVersion, nil
+}
+
+// Stores the request in S3 and returns a request with claim check reference instead.
+func (c *LambdaClient) writeClaimCheck(ctx context.Context, correlationId string, cmd string,
+	requestId interface{}, payload []byte) ([]byte, error) {
 
+	reference, err := c.ClaimCheck.Put(ctx, correlationId, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Logger.Trace(correlationId, "Request to %s was stored in S3 as %s", cmd, reference.Key)
+	return json.Marshal(map[string]interface{}{
+		"cmd":                  cmd,
+		"correlation_id":       requestId,
+		awscon.ClaimCheckParam: reference,
+	})
+}
+
+// Reads the response from S3 when the function returned claim check reference.
+func (c *LambdaClient) readClaimCheck(ctx context.Context, correlationId string, payload string) (string, error) {
+	// References are small, so there is no need to parse large responses
+	if !c.ClaimCheck.IsEnabled() || len(payload) > 1024 {
+		return payload, nil
+	}
+
+	var response map[string]interface{}
+	if json.Unmarshal([]byte(payload), &response) != nil {
+		return payload, nil
+	}
+	reference := awscon.ParseClaimCheckReference(response)
+	if reference == nil {
+		return payload, nil
+	}
+
+	result, err := c.ClaimCheck.Get(ctx, correlationId, reference)
+	if err != nil {
+		return "", err
+	}
+	return (string)(result), nil
 }
 
 // Calls a AWS Lambda Function action.
@@ -267,6 +588,32 @@ func (c *LambdaClient) Call(prototype reflect.Type, cmd string, correlationId st
 	return c.Invoke(prototype, "RequestResponse", cmd, correlationId, params)
 }
 
+// Calls a AWS Lambda Function action that honours deadline and cancellation of the context.
+//   - ctx               a context to cancel the call.
+// 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
+//   - cmd               an action name to be called.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - params            (optional) action parameters.
+//   - Returns           result and error.
+func (c *LambdaClient) CallWithContext(ctx context.Context, prototype reflect.Type, cmd string, correlationId string,
+	params map[string]interface{}) (result interface{}, err error) {
+	result, _, err = c.InvokeWithContext(ctx, prototype, "RequestResponse", "", cmd, correlationId, params)
+	return result, err
+}
+
+// Calls a AWS Lambda Function action on specific version or alias,
+// i.e. for canary checks.
+// 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
+//   - qualifier         a function version or alias that overrides configured qualifier.
+//   - cmd               an action name to be called.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - params            (optional) action parameters.
+//   - Returns           result, the function version that was executed and error.
+func (c *LambdaClient) CallWithQualifier(prototype reflect.Type, qualifier string, cmd string, correlationId string,
+	params map[string]interface{}) (result interface{}, executedVersion string, err error) {
+	return c.InvokeWithQualifier(prototype, "RequestResponse", qualifier, cmd, correlationId, params)
+}
+
 // Calls a AWS Lambda Function action asynchronously without waiting for response.
 // 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
 //   - cmd               an action name to be called.
@@ -277,3 +624,100 @@ func (c *LambdaClient) CallOneWay(prototype reflect.Type, cmd string, correlatio
 	_, err := c.Invoke(prototype, "Event", cmd, correlationId, params)
 	return err
 }
+
+// Calls a AWS Lambda Function action asynchronously without waiting for response.
+// The call honours deadline and cancellation of the context.
+//   - ctx               a context to cancel the call.
+// 	 - prototype reflect.Type type for convert result. Set nil for return raw []byte
+//   - cmd               an action name to be called.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - params            (optional) action parameters.
+//   - Returns           error or null for success.
+func (c *LambdaClient) CallOneWayWithContext(ctx context.Context, prototype reflect.Type, cmd string, correlationId string,
+	params map[string]interface{}) error {
+	_, _, err := c.InvokeWithContext(ctx, prototype, "Event", "", cmd, correlationId, params)
+	return err
+}
+
+// Calls AWS Lambda Function actions in parallel with concurrency limited by "options.max_concurrency".
+// Results and errors are returned in the order of the calls.
+// In fail-fast mode the first error cancels running calls and calls that were not started yet,
+// in collect-all mode all calls are executed and errors are returned per call.
+//   - ctx               a context to cancel the calls.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - calls             a list of calls to execute.
+//   - failFast          true to stop on the first error, false to collect all results.
+// Returns results of the calls and the first error in fail-fast mode.
+func (c *LambdaClient) CallMany(ctx context.Context, correlationId string, calls []*LambdaCall,
+	failFast bool) (results []*LambdaCallResult, err error) {
+	return c.callMany(ctx, "", correlationId, calls, failFast)
+}
+
+func (c *LambdaClient) callMany(ctx context.Context, name string, correlationId string, calls []*LambdaCall,
+	failFast bool) (results []*LambdaCallResult, err error) {
+
+	if correlationId == "" {
+		correlationId = CorrelationIdFromContext(ctx)
+	}
+	timing := c.Instrument(correlationId, name+"call_many")
+	defer timing.EndTiming()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxConcurrency := c.maxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(calls)
+	}
+
+	results = make([]*LambdaCallResult, len(calls))
+	semaphore := make(chan struct{}, maxConcurrency)
+	wg := sync.WaitGroup{}
+	var errOnce sync.Once
+
+	for index, call := range calls {
+		// Wait for a free slot unless the calls are canceled
+		acquired := false
+		select {
+		case semaphore <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			if acquired {
+				<-semaphore
+			}
+			results[index] = &LambdaCallResult{Err: ConvertInvokeError(correlationId, ctx.Err())}
+			continue
+		}
+
+		wg.Add(1)
+		go func(index int, call *LambdaCall) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			// Copy parameters, since they are modified by the call
+			params := make(map[string]interface{})
+			for key, value := range call.Params {
+				params[key] = value
+			}
+
+			c.Counters.IncrementOne(name + call.Cmd + ".call_count")
+			result, callErr := c.CallWithContext(ctx, call.Prototype, call.Cmd, correlationId, params)
+			results[index] = &LambdaCallResult{Result: result, Err: callErr}
+
+			if callErr != nil {
+				c.Counters.IncrementOne(name + call.Cmd + ".call_errors")
+				if failFast {
+					errOnce.Do(func() {
+						err = callErr
+						cancel()
+					})
+				}
+			}
+		}(index, call)
+	}
+
+	wg.Wait()
+	return results, err
+}
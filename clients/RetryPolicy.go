@@ -0,0 +1,133 @@
+package clients
+
+import (
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Retry policy with exponential backoff used by LambdaClient.
+
+Only calls that failed with retryable errors are retried: lost connections,
+timeouts, throttling and temporary unavailable services.
+To respect idempotency, only commands that opted in are retried.
+One-way calls are never retried unless "options.retry_one_way" is set.
+
+### Configuration parameters ###
+
+ - options:
+     - retries:                     (optional) number of retries (default: 3)
+     - retry_min_timeout:           (optional) minimum timeout between retries in milliseconds (default: 100)
+     - retry_max_timeout:           (optional) maximum timeout between retries in milliseconds (default: 10 sec)
+     - retry_jitter:                (optional) true to randomize timeouts between retries (default: true)
+     - retry_commands:              (optional) comma-separated list of idempotent commands to retry, "*" for all commands
+     - retry_one_way:               (optional) true to retry one-way calls (default: false)
+*/
+type RetryPolicy struct {
+	// Number of retries
+	Retries int
+	// Minimum timeout between retries in milliseconds
+	MinTimeout int
+	// Maximum timeout between retries in milliseconds
+	MaxTimeout int
+	// Randomize timeouts between retries
+	Jitter bool
+	// Retry one-way calls
+	RetryOneWay bool
+
+	commands map[string]bool
+}
+
+// NewRetryPolicy creates a new instance of the retry policy with default parameters.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Retries:    3,
+		MinTimeout: 100,
+		MaxTimeout: 10000,
+		Jitter:     true,
+		commands:   make(map[string]bool),
+	}
+}
+
+// Configures component by passing configuration parameters.
+//   - config    configuration parameters to be set.
+func (c *RetryPolicy) Configure(config *cconf.ConfigParams) {
+	c.Retries = config.GetAsIntegerWithDefault("options.retries", c.Retries)
+	c.MinTimeout = config.GetAsIntegerWithDefault("options.retry_min_timeout", c.MinTimeout)
+	c.MaxTimeout = config.GetAsIntegerWithDefault("options.retry_max_timeout", c.MaxTimeout)
+	c.Jitter = config.GetAsBooleanWithDefault("options.retry_jitter", c.Jitter)
+	c.RetryOneWay = config.GetAsBooleanWithDefault("options.retry_one_way", c.RetryOneWay)
+
+	commands := config.GetAsStringWithDefault("options.retry_commands", "")
+	for _, cmd := range strings.Split(commands, ",") {
+		if cmd = strings.TrimSpace(cmd); cmd != "" {
+			c.commands[cmd] = true
+		}
+	}
+}
+
+// Marks commands as idempotent, so they can be safely retried.
+//   - cmds    command names or "*" for all commands.
+func (c *RetryPolicy) AddIdempotentCommands(cmds ...string) {
+	for _, cmd := range cmds {
+		c.commands[cmd] = true
+	}
+}
+
+// Checks if the command is idempotent and can be retried.
+//   - cmd    a command name.
+// Returns true if the command can be retried.
+func (c *RetryPolicy) IsIdempotent(cmd string) bool {
+	return c.commands["*"] || c.commands[cmd]
+}
+
+// Checks if the call of the command shall be retried.
+//   - cmd       a command name.
+//   - oneWay    true for one-way calls.
+// Returns true if the call shall be retried on retryable errors.
+func (c *RetryPolicy) CanRetry(cmd string, oneWay bool) bool {
+	if c.Retries <= 0 || (oneWay && !c.RetryOneWay) {
+		return false
+	}
+	return c.IsIdempotent(cmd)
+}
+
+// Checks if the error is temporary and the call can be retried:
+// lost connection, timeout, throttling or temporary unavailable service.
+//   - err    an error returned by the call.
+// Returns true if the error is retryable.
+func (c *RetryPolicy) IsRetryable(err error) bool {
+	appErr, ok := err.(*cerr.ApplicationError)
+	if !ok || appErr.Code == "CIRCUIT_OPEN" {
+		return false
+	}
+
+	if appErr.Category == cerr.NoResponse || appErr.Code == "TOO_MANY_REQUESTS" {
+		return true
+	}
+	return appErr.Status == http.StatusBadGateway || appErr.Status == http.StatusServiceUnavailable
+}
+
+// Calculates timeout before the retry attempt with exponential backoff.
+//   - attempt    a retry attempt starting from 1.
+// Returns timeout before the retry.
+func (c *RetryPolicy) GetTimeout(attempt int) time.Duration {
+	timeout := c.MinTimeout
+	for i := 1; i < attempt && timeout < c.MaxTimeout; i++ {
+		timeout *= 2
+	}
+	if timeout > c.MaxTimeout {
+		timeout = c.MaxTimeout
+	}
+
+	// Randomize the second half of the timeout to avoid retry storms
+	if c.Jitter && timeout > 1 {
+		timeout = timeout/2 + rand.Intn(timeout/2+1)
+	}
+	return time.Duration(timeout) * time.Millisecond
+}
@@ -0,0 +1,455 @@
+package generator
+
+import (
+	"bytes"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	ccomands "github.com/pip-services3-go/pip-services3-commons-go/commands"
+	cconv "github.com/pip-services3-go/pip-services3-commons-go/convert"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	cvalid "github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+const (
+	clientsPackagePath = "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	dataPackagePath    = "github.com/pip-services3-go/pip-services3-commons-go/data"
+)
+
+// Names used in generated methods that cannot be used for command parameters
+var generatedReservedNames = map[string]bool{
+	"c": true, "correlationId": true, "params": true, "result": true, "err": true,
+	"calValue": true, "calErr": true, "value": true, "ok": true,
+}
+
+/*
+Generates source code of typed clients for commandable AWS Lambda functions.
+The generated client embeds CommandableLambdaClient and has a method per command
+of the controller's CommandSet. Method parameters are taken from command schemas,
+parameters are passed via CallCommand in the same way as in hand-written clients.
+The generator also emits an interface implemented by the client.
+
+Commands do not declare their results, so results are returned as interface{}
+unless result types are set by SetResultType. Types of parameters are derived
+from type codes in command schemas and can be overriden by SetParamType.
+
+The generator is intended to be used in a small program called by "go generate",
+that builds the command set, so the client always stays in sync with the server.
+
+### Example ###
+
+    // In client package: //go:generate go run ./generate
+
+    // generate/main.go
+    func main() {
+        gen := generator.NewCommandableLambdaClientGenerator("clients", "MyLambdaClient", "my_service")
+        gen.SetResultType("get_mydata", reflect.TypeOf(&MyData{}))
+        gen.SetParamType("set_mydata", "data", reflect.TypeOf(MyData{}))
+
+        commandSet := logic.NewMyCommandSet(nil)
+        err := gen.GenerateFile(&commandSet.CommandSet, "MyLambdaClient.gen.go")
+        if err != nil {
+            panic(err)
+        }
+    }
+*/
+type CommandableLambdaClientGenerator struct {
+	// Name of the package for generated code
+	Package string
+	// Name of the generated client struct
+	ClientName string
+	// Name of the generated interface. Default is "I" + ClientName
+	InterfaceName string
+	// Name of the remote function passed to NewCommandableLambdaClient
+	Name string
+	// Aliases of imported packages by package paths
+	Imports map[string]string
+
+	resultTypes map[string]reflect.Type
+	paramTypes  map[string]reflect.Type
+}
+
+// NewCommandableLambdaClientGenerator creates a new instance of the generator.
+//   - pkg           a name of the package for generated code.
+//   - clientName    a name of the generated client struct.
+//   - name          a name of the remote function used to instrument calls.
+func NewCommandableLambdaClientGenerator(pkg string, clientName string, name string) *CommandableLambdaClientGenerator {
+	return &CommandableLambdaClientGenerator{
+		Package:     pkg,
+		ClientName:  clientName,
+		Name:        name,
+		Imports:     make(map[string]string),
+		resultTypes: make(map[string]reflect.Type),
+		paramTypes:  make(map[string]reflect.Type),
+	}
+}
+
+// Sets type of the result returned by a command.
+//   - cmd     a command name.
+//   - typ     a result type, i.e. reflect.TypeOf(&MyData{}).
+func (c *CommandableLambdaClientGenerator) SetResultType(cmd string, typ reflect.Type) {
+	c.resultTypes[cmd] = typ
+}
+
+// Sets type of a command parameter instead of the type taken from the command schema.
+//   - cmd     a command name.
+//   - param   a parameter name in the command schema.
+//   - typ     a parameter type, i.e. reflect.TypeOf(MyData{}).
+func (c *CommandableLambdaClientGenerator) SetParamType(cmd string, param string, typ reflect.Type) {
+	c.paramTypes[cmd+"."+param] = typ
+}
+
+// Generates source code of the client for commands in the command set.
+//   - commandSet    a command set of the controller exposed by the function.
+// Returns formatted source code or error.
+func (c *CommandableLambdaClientGenerator) Generate(commandSet *ccomands.CommandSet) ([]byte, error) {
+	if commandSet == nil {
+		return nil, cerr.NewBadRequestError("", "NO_COMMAND_SET", "Command set is not set")
+	}
+	if c.Package == "" || c.ClientName == "" {
+		return nil, cerr.NewBadRequestError("", "NO_CLIENT_NAME", "Package and client name must be set")
+	}
+
+	imports := newGeneratedImports(c.Package, c.Imports)
+	imports.Add(clientsPackagePath, "awsclient")
+	imports.Add(dataPackagePath, "cdata")
+	imports.Add("reflect", "reflect")
+
+	data := &generatedClient{
+		Package:       c.Package,
+		ClientName:    c.ClientName,
+		InterfaceName: c.InterfaceName,
+		Name:          c.Name,
+		Methods:       make([]*generatedMethod, 0),
+	}
+	if data.InterfaceName == "" {
+		data.InterfaceName = "I" + c.ClientName
+	}
+
+	for _, command := range commandSet.Commands() {
+		method, err := c.createMethod(imports, command)
+		if err != nil {
+			return nil, err
+		}
+		data.Methods = append(data.Methods, method)
+	}
+	data.Imports = imports.List()
+
+	var buffer bytes.Buffer
+	if err := generatedClientTemplate.Execute(&buffer, data); err != nil {
+		return nil, cerr.NewInternalError("", "GENERATION_FAILED", "Failed to generate client").WithCause(err)
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, cerr.NewInternalError("", "GENERATION_FAILED", "Failed to format generated client").WithCause(err)
+	}
+	return source, nil
+}
+
+// Generates source code of the client and writes it into a file.
+//   - commandSet    a command set of the controller exposed by the function.
+//   - fileName      a name of the generated file.
+// Returns error or nil no errors occured.
+func (c *CommandableLambdaClientGenerator) GenerateFile(commandSet *ccomands.CommandSet, fileName string) error {
+	source, err := c.Generate(commandSet)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, source, 0644)
+}
+
+func (c *CommandableLambdaClientGenerator) createMethod(imports *generatedImports, command ccomands.ICommand) (*generatedMethod, error) {
+	cmd := command.Name()
+	method := &generatedMethod{
+		Name:   generatedIdentifier(cmd, true),
+		Cmd:    cmd,
+		Params: make([]*generatedParam, 0),
+	}
+	if method.Name == "" {
+		return nil, cerr.NewBadRequestError("", "INVALID_COMMAND", "Command "+cmd+" cannot be converted into method name").
+			WithDetails("command", cmd)
+	}
+
+	resultType, ok := c.resultTypes[cmd]
+	if !ok {
+		resultType = reflect.TypeOf((*interface{})(nil)).Elem()
+	}
+	method.ResultType = imports.TypeName(resultType)
+	method.ResultPointer = resultType.Kind() == reflect.Ptr
+	if method.ResultPointer {
+		method.Prototype = method.ResultType
+	} else {
+		method.Prototype = "*" + method.ResultType
+	}
+	switch resultType.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		method.ResultZero = "nil"
+	default:
+		method.ResultZero = "result"
+	}
+
+	var schema *cvalid.ObjectSchema
+	if commandWithSchema, ok := command.(interface{ GetSchema() cvalid.ISchema }); ok {
+		schema, _ = commandWithSchema.GetSchema().(*cvalid.ObjectSchema)
+	}
+	if schema == nil {
+		return method, nil
+	}
+
+	for _, property := range schema.Properties() {
+		name := property.Name()
+		paramType, ok := c.paramTypes[cmd+"."+name]
+		typeName := ""
+		if ok {
+			typeName = imports.TypeName(paramType)
+		} else {
+			typeName = generatedSchemaType(imports, property.Type())
+		}
+
+		paramName := generatedIdentifier(name, false)
+		if paramName == "" {
+			return nil, cerr.NewBadRequestError("", "INVALID_PARAMETER", "Parameter "+name+" cannot be converted into argument name").
+				WithDetails("command", cmd).
+				WithDetails("parameter", name)
+		}
+		if generatedReservedNames[paramName] || token.Lookup(paramName).IsKeyword() {
+			paramName += "Param"
+		}
+
+		method.Params = append(method.Params, &generatedParam{
+			Name:  paramName,
+			Key:   name,
+			Type:  typeName,
+			Field: strconv.Quote(name),
+		})
+	}
+	return method, nil
+}
+
+// Converts type of a schema property into Go type.
+func generatedSchemaType(imports *generatedImports, typ interface{}) string {
+	switch value := typ.(type) {
+	case cconv.TypeCode:
+		switch value {
+		case cconv.String, cconv.Enum:
+			return "string"
+		case cconv.Boolean:
+			return "bool"
+		case cconv.Integer:
+			return "int"
+		case cconv.Long:
+			return "int64"
+		case cconv.Float:
+			return "float32"
+		case cconv.Double:
+			return "float64"
+		case cconv.DateTime:
+			imports.Add("time", "time")
+			return "time.Time"
+		case cconv.Duration:
+			imports.Add("time", "time")
+			return "time.Duration"
+		case cconv.Map:
+			return "map[string]interface{}"
+		case cconv.Array:
+			return "[]interface{}"
+		}
+	case cvalid.ISchema:
+		switch schema := value.(type) {
+		case *cvalid.ArraySchema:
+			return "[]" + generatedSchemaType(imports, schema.ValueType())
+		case *cvalid.MapSchema:
+			return "map[string]" + generatedSchemaType(imports, schema.ValueType())
+		}
+	}
+	return "interface{}"
+}
+
+// Converts snake_case or kebab-case name into Go identifier.
+func generatedIdentifier(name string, exported bool) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+
+	result := ""
+	for index, part := range parts {
+		if index == 0 && !exported {
+			result += strings.ToLower(part[:1]) + part[1:]
+		} else {
+			result += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	if result == "" || (result[0] >= '0' && result[0] <= '9') {
+		return ""
+	}
+	return result
+}
+
+type generatedImports struct {
+	pkg     string
+	aliases map[string]string
+	paths   map[string]string
+}
+
+func newGeneratedImports(pkg string, aliases map[string]string) *generatedImports {
+	c := &generatedImports{
+		pkg:     pkg,
+		aliases: make(map[string]string),
+		paths:   make(map[string]string),
+	}
+	for path, alias := range aliases {
+		c.Add(path, alias)
+	}
+	return c
+}
+
+// Adds an import and returns its alias. Conflicting aliases get numeric suffixes.
+func (c *generatedImports) Add(pkgPath string, alias string) string {
+	if existing, ok := c.aliases[pkgPath]; ok {
+		return existing
+	}
+
+	name := alias
+	for index := 2; name == c.pkg || c.paths[name] != ""; index++ {
+		name = alias + strconv.Itoa(index)
+	}
+	c.aliases[pkgPath] = name
+	c.paths[name] = pkgPath
+	return name
+}
+
+// Gets Go name of the type and adds imports of used packages.
+func (c *generatedImports) TypeName(typ reflect.Type) string {
+	if typ.Name() != "" {
+		if typ.PkgPath() == "" {
+			return typ.Name()
+		}
+		alias := c.Add(typ.PkgPath(), strings.SplitN(typ.String(), ".", 2)[0])
+		return alias + "." + typ.Name()
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return "*" + c.TypeName(typ.Elem())
+	case reflect.Slice:
+		return "[]" + c.TypeName(typ.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(typ.Len()) + "]" + c.TypeName(typ.Elem())
+	case reflect.Map:
+		return "map[" + c.TypeName(typ.Key()) + "]" + c.TypeName(typ.Elem())
+	case reflect.Interface:
+		if typ.NumMethod() == 0 {
+			return "interface{}"
+		}
+	}
+	return typ.String()
+}
+
+// Gets imports sorted by package path.
+func (c *generatedImports) List() []*generatedImport {
+	result := make([]*generatedImport, 0, len(c.aliases))
+	for pkgPath, alias := range c.aliases {
+		item := &generatedImport{
+			Path: strconv.Quote(pkgPath),
+			Std:  !strings.Contains(strings.Split(pkgPath, "/")[0], "."),
+		}
+		if alias != path.Base(pkgPath) {
+			item.Alias = alias
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result
+}
+
+type generatedImport struct {
+	Alias string
+	Path  string
+	Std   bool
+}
+
+type generatedParam struct {
+	Name  string
+	Key   string
+	Type  string
+	Field string
+}
+
+type generatedMethod struct {
+	Name          string
+	Cmd           string
+	Params        []*generatedParam
+	ResultType    string
+	ResultPointer bool
+	ResultZero    string
+	Prototype     string
+}
+
+type generatedClient struct {
+	Package       string
+	ClientName    string
+	InterfaceName string
+	Name          string
+	Imports       []*generatedImport
+	Methods       []*generatedMethod
+}
+
+var generatedClientTemplate = template.Must(template.New("client").Parse(`// Code generated by CommandableLambdaClientGenerator. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}{{if .Std}}
+	{{.Alias}} {{.Path}}
+{{- end}}{{end}}
+{{range .Imports}}{{if not .Std}}
+	{{.Alias}} {{.Path}}
+{{- end}}{{end}}
+)
+
+type {{.InterfaceName}} interface {
+{{- range .Methods}}
+	{{.Name}}(correlationId string{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (result {{.ResultType}}, err error)
+{{- end}}
+}
+
+type {{.ClientName}} struct {
+	*awsclient.CommandableLambdaClient
+}
+
+func New{{.ClientName}}() *{{.ClientName}} {
+	c := &{{.ClientName}}{
+		CommandableLambdaClient: awsclient.NewCommandableLambdaClient({{printf "%q" .Name}}),
+	}
+	return c
+}
+{{range .Methods}}
+func (c *{{$.ClientName}}) {{.Name}}(correlationId string{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (result {{.ResultType}}, err error) {
+
+	params := cdata.NewEmptyAnyValueMap()
+{{- range .Params}}
+	params.SetAsObject({{.Field}}, {{.Name}})
+{{- end}}
+
+	calValue, calErr := c.CallCommand(reflect.TypeOf(({{.Prototype}})(nil)), {{printf "%q" .Cmd}}, correlationId, params)
+	if calErr != nil {
+		return {{.ResultZero}}, calErr
+	}
+{{if .ResultPointer}}
+	result, _ = calValue.({{.Prototype}})
+{{- else}}
+	if value, ok := calValue.({{.Prototype}}); ok && value != nil {
+		result = *value
+	}
+{{- end}}
+	return result, nil
+}
+{{end}}`))
@@ -0,0 +1,217 @@
+package connect
+
+import (
+	"regexp"
+	"strings"
+
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+var (
+	arnAccountRegex   = regexp.MustCompile(`^\d+$`)
+	lambdaNameRegex   = regexp.MustCompile(`^[a-zA-Z0-9-_]{1,64}$`)
+	lambdaQualRegex   = regexp.MustCompile(`^(\$LATEST|[a-zA-Z0-9-_]{1,128})$`)
+	sqsQueueNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-_]{1,80}(\.fifo)?$`)
+)
+
+/*
+Amazon Resource Name (ARN) that uniquely identifies AWS resource.
+
+ARNs have "arn:partition:service:region:account:resource" format,
+where resource part is service specific, like "function:name:alias" for Lambda,
+"log-group:name" for CloudWatch Logs or just a queue name for SQS.
+
+### Example ###
+
+    arn, err := ParseAwsArn("123", "arn:aws:lambda:us-east-1:123456789012:function:my-function:prod")
+
+    arn.Service          // Result: "lambda"
+    arn.ResourceType     // Result: "function"
+    arn.Resource         // Result: "my-function"
+    arn.Qualifier        // Result: "prod"
+*/
+type AwsArn struct {
+	// AWS partition, like "aws" or "aws-cn"
+	Partition string
+	// AWS service, like "lambda" or "s3"
+	Service string
+	// AWS region
+	Region string
+	// AWS account id
+	Account string
+	// Type of the resource, like "function" or "log-group"
+	ResourceType string
+	// Resource id or name
+	Resource string
+	// Lambda function version or alias, or layer version
+	Qualifier string
+
+	delimiter string
+}
+
+// NewAwsArn creates a new ARN from its parts.
+//   - partition       an AWS partition. Default is "aws".
+//   - service         an AWS service.
+//   - region          an AWS region.
+//   - account         an AWS account id.
+//   - resourceType    (optional) a resource type.
+//   - resource        a resource id.
+func NewAwsArn(partition string, service string, region string, account string,
+	resourceType string, resource string) *AwsArn {
+
+	if partition == "" {
+		partition = "aws"
+	}
+	return &AwsArn{
+		Partition:    partition,
+		Service:      service,
+		Region:       region,
+		Account:      account,
+		ResourceType: resourceType,
+		Resource:     resource,
+		delimiter:    ":",
+	}
+}
+
+func newArnError(correlationId string, value string, message string) error {
+	return cerr.NewConfigError(
+		correlationId,
+		"INVALID_ARN",
+		"Invalid ARN "+value+": "+message).
+		WithDetails("arn", value)
+}
+
+// ParseAwsArn parses ARN string and splits service specific resource part.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - value             an ARN string.
+// Returns the parsed ARN or ConfigError when the ARN is malformed.
+func ParseAwsArn(correlationId string, value string) (*AwsArn, error) {
+	tokens := strings.SplitN(value, ":", 6)
+	if len(tokens) < 6 || tokens[0] != "arn" {
+		return nil, newArnError(correlationId, value, "expected arn:partition:service:region:account:resource format")
+	}
+
+	arn := &AwsArn{
+		Partition: tokens[1],
+		Service:   tokens[2],
+		Region:    tokens[3],
+		Account:   tokens[4],
+		delimiter: ":",
+	}
+	resource := tokens[5]
+
+	switch arn.Service {
+	case "lambda":
+		// function:name[:qualifier], layer:name[:version] or event-source-mapping:id
+		parts := strings.SplitN(resource, ":", 3)
+		if len(parts) == 1 {
+			parts = strings.SplitN(resource, "/", 2)
+			arn.delimiter = "/"
+		}
+		arn.ResourceType = parts[0]
+		if len(parts) > 1 {
+			arn.Resource = parts[1]
+		}
+		if len(parts) > 2 {
+			arn.Qualifier = parts[2]
+		}
+	case "sqs", "sns", "s3":
+		// Resource names may contain ":" or "/" (SNS subscriptions, S3 object keys)
+		arn.Resource = resource
+	default:
+		// resource-type/resource or resource-type:resource, like "log-group:name:*"
+		slash := strings.Index(resource, "/")
+		colon := strings.Index(resource, ":")
+		if colon > 0 && (slash < 0 || colon < slash) {
+			arn.ResourceType = resource[:colon]
+			arn.Resource = resource[colon+1:]
+		} else if slash > 0 {
+			arn.ResourceType = resource[:slash]
+			arn.Resource = resource[slash+1:]
+			arn.delimiter = "/"
+		} else {
+			arn.Resource = resource
+		}
+	}
+
+	if err := arn.Validate(correlationId); err != nil {
+		return nil, err
+	}
+	return arn, nil
+}
+
+// Validates the ARN using service specific rules.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+// Returns ConfigError or nil if validation passed successfully.
+func (c *AwsArn) Validate(correlationId string) error {
+	value := c.String()
+
+	if c.Partition == "" {
+		return newArnError(correlationId, value, "partition is missing")
+	}
+	if c.Service == "" {
+		return newArnError(correlationId, value, "service is missing")
+	}
+	// Regions are not checked against AWS naming to allow custom regions of emulators, like LocalStack
+	if strings.Contains(c.Region, ":") {
+		return newArnError(correlationId, value, "region "+c.Region+" is invalid")
+	}
+	if c.Account != "" && c.Account != "aws" && !arnAccountRegex.MatchString(c.Account) {
+		return newArnError(correlationId, value, "account "+c.Account+" is invalid")
+	}
+	if c.Resource == "" {
+		return newArnError(correlationId, value, "resource is missing")
+	}
+
+	switch c.Service {
+	case "lambda":
+		if c.Region == "" || c.Account == "" {
+			return newArnError(correlationId, value, "region and account are required for lambda")
+		}
+		if c.ResourceType == "function" {
+			if !lambdaNameRegex.MatchString(c.Resource) {
+				return newArnError(correlationId, value, "function name "+c.Resource+" is invalid")
+			}
+			if c.Qualifier != "" && !lambdaQualRegex.MatchString(c.Qualifier) {
+				return newArnError(correlationId, value, "qualifier "+c.Qualifier+" is invalid")
+			}
+		}
+	case "sqs":
+		if c.Region == "" || c.Account == "" {
+			return newArnError(correlationId, value, "region and account are required for sqs")
+		}
+		if !sqsQueueNameRegex.MatchString(c.Resource) {
+			return newArnError(correlationId, value, "queue name "+c.Resource+" is invalid")
+		}
+	case "logs":
+		if c.ResourceType != "log-group" && c.ResourceType != "destination" {
+			return newArnError(correlationId, value, "resource type "+c.ResourceType+" is invalid for logs")
+		}
+	}
+
+	return nil
+}
+
+// Gets the ARN without Lambda qualifier, i.e. unqualified function ARN.
+// Returns the unqualified ARN string.
+func (c *AwsArn) Unqualified() string {
+	result := "arn:" + c.Partition + ":" + c.Service + ":" + c.Region + ":" + c.Account + ":"
+	if c.ResourceType != "" {
+		delimiter := c.delimiter
+		if delimiter == "" {
+			delimiter = ":"
+		}
+		result += c.ResourceType + delimiter
+	}
+	return result + c.Resource
+}
+
+// Formats the ARN into string.
+// Returns the ARN string.
+func (c *AwsArn) String() string {
+	result := c.Unqualified()
+	if c.Qualifier != "" {
+		result += ":" + c.Qualifier
+	}
+	return result
+}
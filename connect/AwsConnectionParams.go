@@ -1,8 +1,6 @@
 package connect
 
 import (
-	"strings"
-
 	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
 	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
 	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
@@ -18,11 +16,21 @@ The struct is able to compose and parse AWS resource ARNs.
 
 ### Configuration parameters ###
 
-  - access_id:     application access id
+  - access_id:     (optional) application access id
   - client_id:     alternative to access_id
-  - access_key:    application secret key
+  - access_key:    (optional) application secret key
   - client_key:    alternative to access_key
   - secret_key:    alternative to access_key
+  - profile:       (optional) named profile in shared AWS credentials and config files
+  - session_token: (optional) session token for temporary credentials
+  - role_arn:      (optional) ARN of the role to assume with STS
+  - external_id:   (optional) external id to assume the role
+  - role_session_name: (optional) session name of the assumed role
+  - sts_endpoint:  (optional) custom STS endpoint to assume the role
+
+When access_id and access_key are not set, credentials are taken from
+the standard AWS provider chain: environment variables, shared credentials files,
+web identity tokens, ECS container and EC2 instance metadata.
 
 In addition to standard parameters CredentialParams may contain any number of custom parameters
 
@@ -192,35 +200,104 @@ func (c *AwsConnectionParams) GetArn() string {
 
 // SetArn sets the AWS resource ARN.
 // When it sets the value, it automatically parses the ARN
-// and sets individual parameters.
+// and sets individual parameters. Malformed ARNs are kept as is
+// and reported by Validate.
 //   - value a new AWS resource ARN.
 func (c *AwsConnectionParams) SetArn(value string) {
 
 	c.Put("arn", value)
 
 	if value != "" {
-		tokens := strings.Split(value, ":")
-		c.SetPartition(tokens[1])
-		c.SetService(tokens[2])
-		c.SetRegion(tokens[3])
-		c.SetAccount(tokens[4])
-		if len(tokens) > 6 {
-			c.SetResourceType(tokens[5])
-			c.SetResource(tokens[6])
-		} else {
-			temp := tokens[5]
-			pos := strings.Index(temp, "/")
-			if pos > 0 {
-				c.SetResourceType(temp[:pos])
-				c.SetResource(temp[pos+1:])
-			} else {
-				c.SetResourceType("")
-				c.SetResource(temp)
-			}
+		arn, err := ParseAwsArn("", value)
+		if err != nil {
+			return
+		}
+		c.SetPartition(arn.Partition)
+		c.SetService(arn.Service)
+		c.SetRegion(arn.Region)
+		c.SetAccount(arn.Account)
+		c.SetResourceType(arn.ResourceType)
+		c.SetResource(arn.Resource)
+		if arn.Qualifier != "" {
+			c.SetQualifier(arn.Qualifier)
 		}
 	}
 }
 
+// GetQualifier gets the Lambda function version or alias.
+// Returns the qualifier or empty string for unqualified function.
+func (c *AwsConnectionParams) GetQualifier() string {
+	res := c.GetAsNullableString("qualifier")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetQualifier sets the Lambda function version or alias.
+//   - value a new qualifier.
+func (c *AwsConnectionParams) SetQualifier(value string) {
+	c.Put("qualifier", value)
+}
+
+// GetEndpoint gets the custom AWS service endpoint.
+// It is used to connect to local emulators or AWS compatible services.
+// Returns the AWS service endpoint or empty string to use the default one.
+func (c *AwsConnectionParams) GetEndpoint() string {
+	res := c.GetAsNullableString("endpoint")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetEndpoint sets the custom AWS service endpoint.
+//   - value a new AWS service endpoint.
+func (c *AwsConnectionParams) SetEndpoint(value string) {
+	c.Put("endpoint", value)
+}
+
+// GetS3ForcePathStyle checks if path-style addressing shall be used for S3 buckets.
+// It is required by most S3 compatible services like LocalStack.
+// Returns true to use path-style addressing.
+func (c *AwsConnectionParams) GetS3ForcePathStyle() bool {
+	return c.GetAsBooleanWithDefault("s3_force_path_style", false)
+}
+
+// SetS3ForcePathStyle sets path-style addressing for S3 buckets.
+//   - value true to use path-style addressing.
+func (c *AwsConnectionParams) SetS3ForcePathStyle(value bool) {
+	c.Put("s3_force_path_style", value)
+}
+
+// GetDisableSsl checks if SSL shall be disabled when connecting to AWS services.
+// Returns true to disable SSL.
+func (c *AwsConnectionParams) GetDisableSsl() bool {
+	return c.GetAsBooleanWithDefault("disable_ssl", false)
+}
+
+// SetDisableSsl enables or disables SSL when connecting to AWS services.
+//   - value true to disable SSL.
+func (c *AwsConnectionParams) SetDisableSsl(value bool) {
+	c.Put("disable_ssl", value)
+}
+
+// GetCaBundle gets the path to custom CA bundle file in PEM format.
+// Returns the path to CA bundle or empty string to use system certificates.
+func (c *AwsConnectionParams) GetCaBundle() string {
+	res := c.GetAsNullableString("ca_bundle")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetCaBundle sets the path to custom CA bundle file in PEM format.
+//   - value a new path to CA bundle.
+func (c *AwsConnectionParams) SetCaBundle(value string) {
+	c.Put("ca_bundle", value)
+}
+
 // GetAccessId gets the AWS access id.
 // Returns the AWS access id.
 func (c *AwsConnectionParams) GetAccessId() string {
@@ -252,6 +329,10 @@ func (c *AwsConnectionParams) GetAccessKey() string {
 	if res != nil {
 		return *res
 	}
+	res = c.GetAsNullableString("secret_key")
+	if res != nil {
+		return *res
+	}
 	return ""
 }
 
@@ -261,6 +342,109 @@ func (c *AwsConnectionParams) SetAccessKey(value string) {
 	c.Put("access_key", value)
 }
 
+// GetProfile gets the named profile in shared AWS credentials and config files.
+// Returns the profile name or empty string to use the default profile.
+func (c *AwsConnectionParams) GetProfile() string {
+	res := c.GetAsNullableString("profile")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetProfile sets the named profile in shared AWS credentials and config files.
+//   - value a new profile name.
+func (c *AwsConnectionParams) SetProfile(value string) {
+	c.Put("profile", value)
+}
+
+// GetSessionToken gets the session token for temporary credentials.
+// Returns the session token or empty string.
+func (c *AwsConnectionParams) GetSessionToken() string {
+	res := c.GetAsNullableString("session_token")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetSessionToken sets the session token for temporary credentials.
+//   - value a new session token.
+func (c *AwsConnectionParams) SetSessionToken(value string) {
+	c.Put("session_token", value)
+}
+
+// GetRoleArn gets the ARN of the role to assume with STS.
+// Returns the role ARN or empty string when no role shall be assumed.
+func (c *AwsConnectionParams) GetRoleArn() string {
+	res := c.GetAsNullableString("role_arn")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetRoleArn sets the ARN of the role to assume with STS.
+//   - value a new role ARN.
+func (c *AwsConnectionParams) SetRoleArn(value string) {
+	c.Put("role_arn", value)
+}
+
+// GetExternalId gets the external id to assume the role.
+// Returns the external id or empty string.
+func (c *AwsConnectionParams) GetExternalId() string {
+	res := c.GetAsNullableString("external_id")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetExternalId sets the external id to assume the role.
+//   - value a new external id.
+func (c *AwsConnectionParams) SetExternalId(value string) {
+	c.Put("external_id", value)
+}
+
+// GetRoleSessionName gets the session name of the assumed role.
+// Returns the role session name or empty string to generate it.
+func (c *AwsConnectionParams) GetRoleSessionName() string {
+	res := c.GetAsNullableString("role_session_name")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetRoleSessionName sets the session name of the assumed role.
+//   - value a new role session name.
+func (c *AwsConnectionParams) SetRoleSessionName(value string) {
+	c.Put("role_session_name", value)
+}
+
+// GetStsEndpoint gets the custom STS endpoint to assume the role.
+// Returns the STS endpoint or empty string to use the default one.
+func (c *AwsConnectionParams) GetStsEndpoint() string {
+	res := c.GetAsNullableString("sts_endpoint")
+	if res != nil {
+		return *res
+	}
+	return ""
+}
+
+// SetStsEndpoint sets the custom STS endpoint to assume the role.
+//   - value a new STS endpoint.
+func (c *AwsConnectionParams) SetStsEndpoint(value string) {
+	c.Put("sts_endpoint", value)
+}
+
+// UseStaticCredentials checks if static access id and key are configured.
+// Otherwise the standard AWS credential provider chain is used.
+// Returns true when static credentials are set.
+func (c *AwsConnectionParams) UseStaticCredentials() bool {
+	return c.GetAccessId() != "" && c.GetAccessKey() != ""
+}
+
 //  NewAwsConnectionParamsFromString creates a new AwsConnectionParams object filled with key-value pairs serialized as a string.
 //    - line 	a string with serialized key-value pairs as "key1=value1;key2=value2;..."
 //    Example: "Key1=123;Key2=ABC;Key3=2016-09-16T00:00:00.00Z"
@@ -282,14 +466,22 @@ func (c *AwsConnectionParams) Validate(correlationId string) *cerr.ApplicationEr
 			"AWS connection is not set")
 	}
 
-	if c.GetAccessId() == "" {
+	// Explicitly configured ARN must be well-formed
+	if value := c.GetAsString("arn"); value != "" {
+		if _, err := ParseAwsArn(correlationId, value); err != nil {
+			return err.(*cerr.ApplicationError)
+		}
+	}
+
+	// Static credentials are optional, but must be set in pairs
+	if c.GetAccessId() == "" && c.GetAccessKey() != "" {
 		return cerr.NewConfigError(
 			correlationId,
 			"NO_ACCESS_ID",
 			"No access_id is configured in AWS credential")
 	}
 
-	if c.GetAccessKey() == "" {
+	if c.GetAccessId() != "" && c.GetAccessKey() == "" {
 		return cerr.NewConfigError(
 			correlationId,
 			"NO_ACCESS_KEY",
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
 	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
 	cauth "github.com/pip-services3-go/pip-services3-components-go/auth"
 	ccon "github.com/pip-services3-go/pip-services3-components-go/connect"
@@ -23,10 +24,20 @@ validate them and compose a AwsConnectionParams value.
      - resource_type:               (optional) AWS resource type
      - resource:                    (optional) AWS resource id
      - arn:                         (optional) AWS resource ARN
+     - endpoint:                    (optional) custom AWS service endpoint, i.e. LocalStack
+     - s3_force_path_style:         (optional) true to use path-style addressing for S3 buckets (default: false)
+     - disable_ssl:                 (optional) true to disable SSL (default: false)
+     - ca_bundle:                   (optional) path to custom CA bundle file in PEM format
+     - sts_endpoint:                (optional) custom STS endpoint to assume the role
  - credentials:
      - store_key:                   (optional) a key to retrieve the credentials from ICredentialStore
-     - access_id:                   AWS access/client id
-     - access_key:                  AWS access/client id
+     - access_id:                   (optional) AWS access/client id
+     - access_key:                  (optional) AWS access/client key
+     - profile:                     (optional) named profile in shared AWS credentials files
+     - session_token:               (optional) session token for temporary credentials
+     - role_arn:                    (optional) ARN of the role to assume with STS
+     - external_id:                 (optional) external id to assume the role
+     - role_session_name:           (optional) session name of the assumed role
 
 ### References ###
 
@@ -119,6 +130,19 @@ func (c *AwsConnectionResolver) Resolve(correlationId string) (connection *AwsCo
 		if credErr == nil && data != nil {
 			connection.Append(data.Value())
 		}
+		if credErr == nil && data == nil {
+			// Credentials with store key must be found in credential stores
+			for _, credential := range c.credentialResolver.GetAll() {
+				if credential.UseCredentialStore() {
+					credErr = cerr.NewConfigError(
+						correlationId,
+						"NO_CREDENTIAL",
+						"Credential "+credential.StoreKey()+" was not found in credential stores").
+						WithDetails("store_key", credential.StoreKey())
+					break
+				}
+			}
+		}
 		globalErr = credErr
 	}()
 	wg.Wait()
@@ -126,8 +150,10 @@ func (c *AwsConnectionResolver) Resolve(correlationId string) (connection *AwsCo
 	if globalErr != nil {
 		return nil, globalErr
 	}
-	// Force ARN parsing
-	connection.SetArn(connection.GetArn())
+	// Force parsing of explicitly configured ARN
+	if arn := connection.GetAsString("arn"); arn != "" {
+		connection.SetArn(arn)
+	}
 	// Perform validation
 	validErr := connection.Validate(correlationId)
 
@@ -0,0 +1,108 @@
+package connect
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Creates a new AWS session from the connection parameters.
+When static access id and key are not configured, credentials are resolved
+by the standard AWS provider chain using the optional named profile.
+When role ARN is configured, the role is assumed with STS and temporary
+credentials are refreshed automatically before they expire.
+Besides region and credentials it applies custom endpoint,
+path-style S3 addressing, disabled SSL and custom CA bundle
+to connect to LocalStack or other AWS compatible services.
+   - correlationId     (optional) transaction id to trace execution through call chain.
+   - connection        AWS connection parameters.
+   - maxRetries        a maximum number of retries for failed requests.
+Returns a new AWS session or error.
+*/
+func NewAwsSession(correlationId string, connection *AwsConnectionParams, maxRetries int) (*session.Session, error) {
+	awsConfig := &aws.Config{
+		MaxRetries: aws.Int(maxRetries),
+	}
+	// Region can also be taken from environment or shared config
+	if region := connection.GetRegion(); region != "" {
+		awsConfig.Region = aws.String(region)
+	}
+	if connection.UseStaticCredentials() {
+		awsConfig.Credentials = credentials.NewStaticCredentials(
+			connection.GetAccessId(), connection.GetAccessKey(), connection.GetSessionToken())
+	}
+	if endpoint := connection.GetEndpoint(); endpoint != "" {
+		awsConfig.Endpoint = aws.String(endpoint)
+	}
+	if connection.GetS3ForcePathStyle() {
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	if connection.GetDisableSsl() {
+		awsConfig.DisableSSL = aws.Bool(true)
+	}
+
+	options := session.Options{
+		Config:            *awsConfig,
+		Profile:           connection.GetProfile(),
+		SharedConfigState: session.SharedConfigEnable,
+	}
+
+	if caBundle := connection.GetCaBundle(); caBundle != "" {
+		file, err := os.Open(caBundle)
+		if err != nil {
+			return nil, cerr.NewConfigError(
+				correlationId,
+				"CANNOT_READ_CA_BUNDLE",
+				"Failed to read CA bundle from "+caBundle).
+				WithDetails("ca_bundle", caBundle).
+				WithCause(err)
+		}
+		defer file.Close()
+		options.CustomCABundle = file
+	}
+
+	sess, err := session.NewSessionWithOptions(options)
+	if err != nil {
+		return nil, cerr.NewConnectionError(
+			correlationId,
+			"CANNOT_CONNECT",
+			"Failed to create AWS session").
+			WithCause(err)
+	}
+
+	if roleArn := connection.GetRoleArn(); roleArn != "" {
+		sess = assumeRole(sess, connection)
+	}
+	return sess, nil
+}
+
+// Creates a copy of the session with credentials of the assumed role.
+// The credentials of the original session are used to call STS.
+func assumeRole(sess *session.Session, connection *AwsConnectionParams) *session.Session {
+	stsConfig := &aws.Config{}
+	if stsEndpoint := connection.GetStsEndpoint(); stsEndpoint != "" {
+		stsConfig.Endpoint = aws.String(stsEndpoint)
+	}
+	stsClient := sts.New(sess, stsConfig)
+
+	roleCred := stscreds.NewCredentialsWithClient(stsClient, connection.GetRoleArn(),
+		func(provider *stscreds.AssumeRoleProvider) {
+			if externalId := connection.GetExternalId(); externalId != "" {
+				provider.ExternalID = aws.String(externalId)
+			}
+			if roleSessionName := connection.GetRoleSessionName(); roleSessionName != "" {
+				provider.RoleSessionName = roleSessionName
+			}
+			// Refresh credentials before they expire
+			provider.ExpiryWindow = time.Minute
+		})
+
+	return sess.Copy(&aws.Config{Credentials: roleCred})
+}
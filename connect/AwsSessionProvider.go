@@ -0,0 +1,240 @@
+package connect
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	clog "github.com/pip-services3-go/pip-services3-components-go/log"
+)
+
+/*
+Component that resolves AWS connection and credential parameters once
+and hands out configured AWS sessions and service clients.
+
+Sessions and clients are created once and cached until the provider is closed.
+Temporary and assumed role credentials are renewed by the session itself.
+The provider can be shared by AWS components via references,
+so retries, timeouts, HTTP transport and logging of SDK requests
+are tuned in one place.
+
+### Configuration parameters ###
+
+ - connections:
+     - discovery_key:               (optional) a key to retrieve the connection from IDiscovery
+     - region:                      (optional) AWS region
+     - endpoint:                    (optional) custom AWS service endpoint, i.e. LocalStack
+     - s3_force_path_style:         (optional) true to use path-style addressing for S3 buckets (default: false)
+     - disable_ssl:                 (optional) true to disable SSL (default: false)
+     - ca_bundle:                   (optional) path to custom CA bundle file in PEM format
+     - sts_endpoint:                (optional) custom STS endpoint to assume the role
+ - credentials:
+     - store_key:                   (optional) a key to retrieve the credentials from ICredentialStore
+     - access_id:                   (optional) AWS access/client id
+     - access_key:                  (optional) AWS access/client key
+     - profile:                     (optional) named profile in shared AWS credentials files
+     - session_token:               (optional) session token for temporary credentials
+     - role_arn:                    (optional) ARN of the role to assume with STS
+     - external_id:                 (optional) external id to assume the role
+     - role_session_name:           (optional) session name of the assumed role
+ - options:
+     - max_retries:                 (optional) maximum number of retries for failed requests (default: 3)
+     - connect_timeout:             (optional) request timeout in milliseconds (default: 30 sec)
+     - max_connections:             (optional) maximum number of connections per host, 0 for unlimited (default: 0)
+     - log_requests:                (optional) true to log SDK requests and responses at trace level (default: false)
+
+### References ###
+
+ - \*:logger:\*:\*:1.0            (optional) ILogger components to pass log messages
+ - \*:discovery:\*:\*:1.0         (optional) IDiscovery services to resolve connection
+ - \*:credential-store:\*:\*:1.0  (optional) Credential stores to resolve credentials
+
+### Example ###
+
+    provider := NewAwsSessionProvider()
+    provider.Configure(NewConfigParamsFromTuples(
+        "connection.region", "us-east-1",
+        "credential.access_id", "XXXXXXXXXXX",
+        "credential.access_key", "XXXXXXXXXXX",
+        "options.max_retries", 5,
+    ))
+
+    err := provider.Open("123")
+    ...
+
+    client, err := provider.GetClient("123", "lambda", func(sess *session.Session) interface{} {
+        return lambda.New(sess)
+    })
+*/
+type AwsSessionProvider struct {
+	connectionResolver *AwsConnectionResolver
+	logger             *clog.CompositeLogger
+	connection         *AwsConnectionParams
+
+	maxRetries     int
+	connectTimeout int
+	maxConnections int
+	logRequests    bool
+
+	lock    sync.Mutex
+	session *session.Session
+	clients map[string]interface{}
+}
+
+// NewAwsSessionProvider creates a new instance of the session provider.
+func NewAwsSessionProvider() *AwsSessionProvider {
+	return &AwsSessionProvider{
+		connectionResolver: NewAwsConnectionResolver(),
+		logger:             clog.NewCompositeLogger(),
+		maxRetries:         3,
+		connectTimeout:     30000,
+		clients:            make(map[string]interface{}),
+	}
+}
+
+// Configures component by passing configuration parameters.
+//   - config    configuration parameters to be set.
+func (c *AwsSessionProvider) Configure(config *cconf.ConfigParams) {
+	c.connectionResolver.Configure(config)
+
+	c.maxRetries = config.GetAsIntegerWithDefault("options.max_retries", c.maxRetries)
+	c.connectTimeout = config.GetAsIntegerWithDefault("options.connect_timeout", c.connectTimeout)
+	c.maxConnections = config.GetAsIntegerWithDefault("options.max_connections", c.maxConnections)
+	c.logRequests = config.GetAsBooleanWithDefault("options.log_requests", c.logRequests)
+}
+
+// Sets references to dependent components.
+//   - references 	references to locate the component dependencies.
+func (c *AwsSessionProvider) SetReferences(references cref.IReferences) {
+	c.logger.SetReferences(references)
+	c.connectionResolver.SetReferences(references)
+}
+
+//  Checks if the component is opened.
+//  Returns true if the component has been opened and false otherwise.
+func (c *AwsSessionProvider) IsOpen() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.connection != nil
+}
+
+// Opens the component and resolves AWS connection.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - Return 			 error or nil no errors occured.
+func (c *AwsSessionProvider) Open(correlationId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.connection != nil {
+		return nil
+	}
+
+	connection, err := c.connectionResolver.Resolve(correlationId)
+	if err != nil {
+		return err
+	}
+
+	c.connection = connection
+	return nil
+}
+
+// Closes component and frees cached sessions and clients.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - Returns 			 error or null no errors occured.
+func (c *AwsSessionProvider) Close(correlationId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.connection = nil
+	c.session = nil
+	c.clients = make(map[string]interface{})
+	return nil
+}
+
+// Gets the resolved AWS connection parameters.
+// Returns the connection parameters or nil if the component is not opened.
+func (c *AwsSessionProvider) GetConnection() *AwsConnectionParams {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.connection
+}
+
+// Gets configured AWS session. The session is created on the first request
+// and cached until the provider is closed.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+// Returns the AWS session or error.
+func (c *AwsSessionProvider) GetSession(correlationId string) (*session.Session, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.getSession(correlationId)
+}
+
+// Gets a configured AWS service client. The client is created by the factory
+// on the first request and cached with the session under the given name.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - name              a unique name of the client, i.e. AWS service name.
+//   - factory           a function that creates the client from AWS session.
+// Returns the AWS service client or error.
+func (c *AwsSessionProvider) GetClient(correlationId string, name string,
+	factory func(sess *session.Session) interface{}) (interface{}, error) {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	sess, err := c.getSession(correlationId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := c.clients[name]
+	if !ok {
+		client = factory(sess)
+		c.clients[name] = client
+	}
+	return client, nil
+}
+
+func (c *AwsSessionProvider) getSession(correlationId string) (*session.Session, error) {
+	if c.connection == nil {
+		return nil, cerr.NewInvalidStateError(
+			correlationId,
+			"NOT_OPENED",
+			"AWS session provider is not opened")
+	}
+
+	if c.session != nil {
+		return c.session, nil
+	}
+
+	sess, err := NewAwsSession(correlationId, c.connection, c.maxRetries)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Timeout: time.Duration(c.connectTimeout) * time.Millisecond,
+	}
+	if c.maxConnections > 0 {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxConnsPerHost = c.maxConnections
+		transport.MaxIdleConnsPerHost = c.maxConnections
+		httpClient.Transport = transport
+	}
+	sess.Config.HTTPClient = httpClient
+
+	if c.logRequests {
+		sess.Config.LogLevel = aws.LogLevel(aws.LogDebugWithHTTPBody)
+		sess.Config.Logger = aws.LoggerFunc(func(args ...interface{}) {
+			c.logger.Trace(correlationId, "%s", fmt.Sprint(args...))
+		})
+	}
+
+	c.session = sess
+	return sess, nil
+}
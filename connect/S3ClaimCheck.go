@@ -0,0 +1,322 @@
+package connect
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	clog "github.com/pip-services3-go/pip-services3-components-go/log"
+)
+
+// Name of the parameter that carries a reference to the payload stored in S3
+const ClaimCheckParam = "claim_check"
+
+// Maximum size of AWS Lambda payloads for synchronous and asynchronous invocations
+const (
+	LambdaMaxSyncPayloadSize  = 6 * 1024 * 1024
+	LambdaMaxAsyncPayloadSize = 256 * 1024
+)
+
+// ClaimCheckReference is a reference to the payload stored in S3 bucket,
+// that is sent instead of the payload itself.
+type ClaimCheckReference struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Size   int    `json:"size"`
+}
+
+// ParseClaimCheckReference gets the reference from "claim_check" parameter of the message.
+//   - message    a request or response message.
+// Returns the reference or nil if the message does not carry it.
+func ParseClaimCheckReference(message map[string]interface{}) *ClaimCheckReference {
+	value, ok := message[ClaimCheckParam].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	reference := &ClaimCheckReference{}
+	reference.Bucket, _ = value["bucket"].(string)
+	reference.Key, _ = value["key"].(string)
+	if size, ok := value["size"].(float64); ok {
+		reference.Size = int(size)
+	}
+
+	if reference.Bucket == "" || reference.Key == "" {
+		return nil
+	}
+	return reference
+}
+
+/*
+Implements "claim check" pattern to pass large payloads to and from AWS Lambda functions.
+Lambda limits synchronous payloads to 6 MB. Payloads above configured threshold
+are written to S3 bucket and only a reference in "claim_check" parameter is sent instead.
+The receiving side fetches the payload and deletes the object.
+
+The component is disabled until the bucket is configured.
+It shall be configured the same way on the client and in the function container.
+
+### Configuration parameters ###
+
+ - bucket:                          S3 bucket to store large payloads
+ - prefix:                          (optional) prefix of object keys (default: "claim-check/")
+ - threshold:                       (optional) payload size in bytes to store in S3 (default: 5 MB)
+ - delete_on_read:                  (optional) true to delete objects after they were read (default: true)
+ - endpoint:                        (optional) custom S3 endpoint, i.e. local S3 stand-in
+ - connections:
+     - region:                      (optional) AWS region
+     - endpoint:                    (optional) custom AWS service endpoint
+ - credentials:
+     - access_id:                   (optional) AWS access/client id
+     - access_key:                  (optional) AWS access/client key
+ - options:
+     - connect_timeout:             (optional) request timeout in milliseconds (default: 30 sec)
+
+### References ###
+
+ - \*:logger:\*:\*:1.0            (optional) ILogger components to pass log messages
+ - \*:discovery:\*:\*:1.0         (optional) IDiscovery services to resolve connection
+ - \*:credential-store:\*:\*:1.0  (optional) Credential stores to resolve credentials
+ - \*:session-provider:aws:\*:1.0 (optional) Shared AwsSessionProvider to create AWS sessions
+
+### Example ###
+
+    claimCheck := NewS3ClaimCheck()
+    claimCheck.Configure(NewConfigParamsFromTuples(
+        "bucket", "my-payloads",
+        "threshold", 1048576,
+        "connection.region", "us-east-1",
+    ))
+    err := claimCheck.Open("123")
+    ...
+
+    if claimCheck.NeedsOffload(len(payload)) {
+        reference, err := claimCheck.Put(ctx, "123", payload)
+        ...
+    }
+*/
+type S3ClaimCheck struct {
+	// The AWS session provider. It is replaced by a shared provider found in references.
+	SessionProvider    *AwsSessionProvider
+	ownSessionProvider bool
+	logger             *clog.CompositeLogger
+
+	bucket       string
+	prefix       string
+	threshold    int
+	deleteOnRead bool
+	endpoint     string
+	client       *s3.S3
+}
+
+// NewS3ClaimCheck creates a new instance of the component.
+func NewS3ClaimCheck() *S3ClaimCheck {
+	return &S3ClaimCheck{
+		SessionProvider:    NewAwsSessionProvider(),
+		ownSessionProvider: true,
+		logger:             clog.NewCompositeLogger(),
+		prefix:             "claim-check/",
+		threshold:          5 * 1024 * 1024,
+		deleteOnRead:       true,
+	}
+}
+
+// Configures component by passing configuration parameters.
+//   - config    configuration parameters to be set.
+func (c *S3ClaimCheck) Configure(config *cconf.ConfigParams) {
+	c.bucket = config.GetAsStringWithDefault("bucket", c.bucket)
+	c.prefix = config.GetAsStringWithDefault("prefix", c.prefix)
+	c.threshold = config.GetAsIntegerWithDefault("threshold", c.threshold)
+	c.deleteOnRead = config.GetAsBooleanWithDefault("delete_on_read", c.deleteOnRead)
+	c.endpoint = config.GetAsStringWithDefault("endpoint", c.endpoint)
+
+	if c.ownSessionProvider {
+		c.SessionProvider.Configure(config)
+	}
+}
+
+// Sets references to dependent components.
+//   - references 	references to locate the component dependencies.
+func (c *S3ClaimCheck) SetReferences(references cref.IReferences) {
+	c.logger.SetReferences(references)
+	c.SessionProvider.SetReferences(references)
+
+	// Use shared session provider when it is available
+	ref := references.GetOneOptional(
+		cref.NewDescriptor("*", "session-provider", "aws", "*", "1.0"))
+	if sessionProvider, ok := ref.(*AwsSessionProvider); ok {
+		c.SetSessionProvider(sessionProvider)
+	}
+}
+
+// Sets AWS session provider owned by another component, i.e. by the client.
+//   - sessionProvider    a session provider to create S3 client.
+func (c *S3ClaimCheck) SetSessionProvider(sessionProvider *AwsSessionProvider) {
+	c.SessionProvider = sessionProvider
+	c.ownSessionProvider = false
+}
+
+// Checks if the bucket is configured and payloads can be offloaded.
+func (c *S3ClaimCheck) IsEnabled() bool {
+	return c.bucket != ""
+}
+
+// Checks if payload of the given size shall be stored in S3.
+//   - size    a payload size in bytes.
+func (c *S3ClaimCheck) NeedsOffload(size int) bool {
+	return c.IsEnabled() && size > c.threshold
+}
+
+//  Checks if the component is opened.
+//  Returns true if the component has been opened and false otherwise.
+func (c *S3ClaimCheck) IsOpen() bool {
+	return c.client != nil
+}
+
+// Opens the component and creates S3 client.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - Return 			 error or nil no errors occured.
+func (c *S3ClaimCheck) Open(correlationId string) error {
+	if c.IsOpen() || !c.IsEnabled() {
+		return nil
+	}
+
+	err := c.SessionProvider.Open(correlationId)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.SessionProvider.GetClient(correlationId, "s3:"+c.endpoint, func(sess *session.Session) interface{} {
+		config := aws.NewConfig()
+		if c.endpoint != "" {
+			config = config.WithEndpoint(c.endpoint).WithS3ForcePathStyle(true)
+		}
+		return s3.New(sess, config)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.client = client.(*s3.S3)
+	return nil
+}
+
+// Closes component and frees used resources.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - Returns 			 error or null no errors occured.
+func (c *S3ClaimCheck) Close(correlationId string) error {
+	c.client = nil
+	if c.ownSessionProvider {
+		return c.SessionProvider.Close(correlationId)
+	}
+	return nil
+}
+
+func (c *S3ClaimCheck) checkOpened(correlationId string) error {
+	if c.client == nil {
+		return cerr.NewInvalidStateError(
+			correlationId,
+			"NOT_OPENED",
+			"S3 claim check is not opened")
+	}
+	return nil
+}
+
+// Stores the payload in S3 bucket.
+//   - ctx               a context to cancel the request.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - payload           a payload to store.
+// Returns a reference to the stored payload or error.
+func (c *S3ClaimCheck) Put(ctx context.Context, correlationId string, payload []byte) (*ClaimCheckReference, error) {
+	if err := c.checkOpened(correlationId); err != nil {
+		return nil, err
+	}
+
+	key := c.prefix + cdata.IdGenerator.NextLong()
+	_, err := c.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(c.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(payload),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return nil, cerr.NewConnectionError(
+			correlationId,
+			"CLAIM_CHECK_PUT_FAILED",
+			"Failed to store payload in S3").
+			WithDetails("bucket", c.bucket).
+			WithDetails("key", key).
+			WithCause(err)
+	}
+
+	return &ClaimCheckReference{Bucket: c.bucket, Key: key, Size: len(payload)}, nil
+}
+
+// Reads the payload from S3 bucket and deletes the object when "delete_on_read" is set.
+//   - ctx               a context to cancel the request.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - reference         a reference to the stored payload.
+// Returns the payload or error.
+func (c *S3ClaimCheck) Get(ctx context.Context, correlationId string, reference *ClaimCheckReference) ([]byte, error) {
+	if err := c.checkOpened(correlationId); err != nil {
+		return nil, err
+	}
+
+	// Do not let the other side read objects outside the configured location
+	if reference.Bucket != c.bucket || !strings.HasPrefix(reference.Key, c.prefix) {
+		return nil, cerr.NewBadRequestError(
+			correlationId,
+			"INVALID_CLAIM_CHECK",
+			"Claim check refers to unexpected S3 location").
+			WithDetails("bucket", reference.Bucket).
+			WithDetails("key", reference.Key)
+	}
+
+	output, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(reference.Bucket),
+		Key:    aws.String(reference.Key),
+	})
+	if err != nil {
+		return nil, cerr.NewConnectionError(
+			correlationId,
+			"CLAIM_CHECK_GET_FAILED",
+			"Failed to read payload from S3").
+			WithDetails("bucket", reference.Bucket).
+			WithDetails("key", reference.Key).
+			WithCause(err)
+	}
+	defer output.Body.Close()
+
+	payload, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return nil, cerr.NewConnectionError(
+			correlationId,
+			"CLAIM_CHECK_GET_FAILED",
+			"Failed to read payload from S3").
+			WithDetails("bucket", reference.Bucket).
+			WithDetails("key", reference.Key).
+			WithCause(err)
+	}
+
+	if c.deleteOnRead {
+		_, err = c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(reference.Bucket),
+			Key:    aws.String(reference.Key),
+		})
+		// The payload was read, so stale objects are left to bucket lifecycle rules
+		if err != nil {
+			c.logger.Warn(correlationId, "Failed to delete payload %s from S3 bucket %s: %s",
+				reference.Key, reference.Bucket, err.Error())
+		}
+	}
+
+	return payload, nil
+}
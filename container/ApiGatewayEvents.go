@@ -0,0 +1,258 @@
+package container
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+func isApiGatewayProxyEvent(event map[string]interface{}) bool {
+	_, hasMethod := event["httpMethod"]
+	_, hasPath := event["path"]
+	return hasMethod && hasPath
+}
+
+// Checks for API Gateway HTTP API and Lambda Function URL events in payload format 2.0
+func isApiGatewayV2HttpEvent(event map[string]interface{}) bool {
+	version, _ := event["version"].(string)
+	_, hasRawPath := event["rawPath"]
+	_, hasRouteKey := event["routeKey"]
+	return version == "2.0" && (hasRawPath || hasRouteKey)
+}
+
+// Finds a command for the HTTP request in registered routes.
+// Requests that do not match any route are not executed.
+//   - method      an HTTP method.
+//   - path        a requested path.
+//   - template    (optional) a route template resolved by API Gateway.
+func (c *LambdaFunction) findRoute(method string, path string, template string) (string, map[string]string) {
+	for _, route := range c.routes {
+		// API Gateway resolves path parameters for matching route templates
+		if template != "" && route.Route == template && route.MatchMethod(method) {
+			return route.Cmd, map[string]string{}
+		}
+		if params, ok := route.Match(method, path); ok {
+			return route.Cmd, params
+		}
+	}
+
+	return "", nil
+}
+
+// Decodes path parameters extracted from URL-encoded path.
+// Parameters that are not valid escape sequences are passed as they are.
+func unescapePathParams(pathParams map[string]string) map[string]string {
+	for name, value := range pathParams {
+		if decoded, err := url.PathUnescape(value); err == nil {
+			pathParams[name] = decoded
+		}
+	}
+	return pathParams
+}
+
+// Composes action parameters from query, body and path parameters.
+func (c *LambdaFunction) composeHttpParams(query map[string]string, multiQuery map[string][]string,
+	body string, isBase64Encoded bool, pathParams ...map[string]string) (map[string]interface{}, error) {
+
+	params := make(map[string]interface{})
+
+	for key, value := range query {
+		params[key] = value
+	}
+	for key, values := range multiQuery {
+		if len(values) > 1 {
+			params[key] = values
+		}
+	}
+
+	if body != "" {
+		data := []byte(body)
+		if isBase64Encoded {
+			decoded, err := base64.StdEncoding.DecodeString(body)
+			if err != nil {
+				return nil, cerr.NewBadRequestError(
+					"",
+					"BAD_BODY",
+					"Failed to decode request body").WithCause(err)
+			}
+			data = decoded
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			params["body"] = string(data)
+		} else if values, ok := value.(map[string]interface{}); ok {
+			for key, value := range values {
+				params[key] = value
+			}
+		} else {
+			params["body"] = value
+		}
+	}
+
+	for _, values := range pathParams {
+		for key, value := range values {
+			params[key] = value
+		}
+	}
+
+	return params, nil
+}
+
+// Executes an action and composes HTTP status code and JSON body from its result.
+func (c *LambdaFunction) executeHttpAction(ctx context.Context, params map[string]interface{}) (int, string) {
+	correlationId, _ := params["correlation_id"].(string)
+	cmd, _ := params["cmd"].(string)
+
+	result, err := c.executeAction(ctx, params)
+	if err != nil {
+		c.Logger().Error(correlationId, err, "Failed to execute %s action", cmd)
+		return c.composeHttpError(err, correlationId)
+	}
+
+	if result == nil {
+		return http.StatusNoContent, ""
+	}
+
+	body, convErr := json.Marshal(result)
+	if convErr != nil {
+		return c.composeHttpError(convErr, correlationId)
+	}
+	return http.StatusOK, string(body)
+}
+
+func getHeader(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// Composes HTTP status code and JSON body from an error.
+// The status code is taken from ApplicationError status.
+func (c *LambdaFunction) composeHttpError(err error, correlationId string) (int, string) {
+	description := cerr.ErrorDescriptionFactory.Create(err)
+	if description.Status == 0 {
+		description.Status = http.StatusInternalServerError
+	}
+	if description.CorrelationId == "" {
+		description.CorrelationId = correlationId
+	}
+	body, _ := json.Marshal(description)
+	return description.Status, string(body)
+}
+
+func (c *LambdaFunction) handleApiGatewayProxy(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var request events.APIGatewayProxyRequest
+	if err := convertEvent(event, &request); err != nil {
+		return nil, err
+	}
+
+	response := events.APIGatewayProxyResponse{
+		Headers: map[string]string{"Content-Type": "application/json"},
+	}
+
+	correlationId := request.RequestContext.RequestID
+	if header := getHeader(request.Headers, "correlation_id"); header != "" {
+		correlationId = header
+	}
+
+	cmd, pathParams := c.findRoute(request.HTTPMethod, request.Path, request.Resource)
+	if cmd == "" {
+		err := cerr.NewNotFoundError(
+			correlationId,
+			"NO_ROUTE",
+			"Route "+request.HTTPMethod+" "+request.Path+" was not found").
+			WithDetails("method", request.HTTPMethod).
+			WithDetails("path", request.Path)
+		response.StatusCode, response.Body = c.composeHttpError(err, correlationId)
+		return response, nil
+	}
+
+	params, err := c.composeHttpParams(request.QueryStringParameters, request.MultiValueQueryStringParameters,
+		request.Body, request.IsBase64Encoded, request.PathParameters, pathParams)
+	if err != nil {
+		response.StatusCode, response.Body = c.composeHttpError(err, correlationId)
+		return response, nil
+	}
+
+	params["cmd"] = cmd
+	if _, ok := params["correlation_id"]; !ok && correlationId != "" {
+		params["correlation_id"] = correlationId
+	}
+
+	response.StatusCode, response.Body = c.executeHttpAction(ctx, params)
+	return response, nil
+}
+
+func (c *LambdaFunction) handleApiGatewayV2Http(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var request events.APIGatewayV2HTTPRequest
+	if err := convertEvent(event, &request); err != nil {
+		return nil, err
+	}
+
+	response := events.APIGatewayV2HTTPResponse{
+		Headers: map[string]string{"Content-Type": "application/json"},
+	}
+
+	correlationId := request.RequestContext.RequestID
+	if header := getHeader(request.Headers, "correlation_id"); header != "" {
+		correlationId = header
+	}
+
+	method := request.RequestContext.HTTP.Method
+	path := request.RawPath
+	if path == "" {
+		path = request.RequestContext.HTTP.Path
+	}
+	// Raw path is matched to keep encoded slashes inside segments
+	encoded := path == request.RawPath
+
+	// Route key has "METHOD /path" format, or "$default" for catch-all routes
+	template := ""
+	if pos := strings.Index(request.RouteKey, " "); pos > 0 {
+		template = request.RouteKey[pos+1:]
+	}
+
+	cmd, pathParams := c.findRoute(method, path, template)
+	if cmd == "" {
+		err := cerr.NewNotFoundError(
+			correlationId,
+			"NO_ROUTE",
+			"Route "+method+" "+path+" was not found").
+			WithDetails("method", method).
+			WithDetails("path", path)
+		response.StatusCode, response.Body = c.composeHttpError(err, correlationId)
+		return response, nil
+	}
+
+	if encoded {
+		pathParams = unescapePathParams(pathParams)
+	}
+
+	params, err := c.composeHttpParams(request.QueryStringParameters, nil,
+		request.Body, request.IsBase64Encoded, request.PathParameters, pathParams)
+	if err != nil {
+		response.StatusCode, response.Body = c.composeHttpError(err, correlationId)
+		return response, nil
+	}
+
+	params["cmd"] = cmd
+	if len(request.Cookies) > 0 {
+		params["cookies"] = request.Cookies
+	}
+	if _, ok := params["correlation_id"]; !ok && correlationId != "" {
+		params["correlation_id"] = correlationId
+	}
+
+	response.StatusCode, response.Body = c.executeHttpAction(ctx, params)
+	return response, nil
+}
@@ -1,6 +1,7 @@
 package container
 
 import (
+	awsserv "github.com/pip-services3-go/pip-services3-aws-go/services"
 	ccomands "github.com/pip-services3-go/pip-services3-commons-go/commands"
 	crun "github.com/pip-services3-go/pip-services3-commons-go/run"
 )
@@ -86,6 +87,8 @@ func (c *CommandableLambdaFunction) registerCommandSet(commandSet *ccomands.Comm
 			timing.EndTiming(errRes)
 			return result, errRes
 		})
+		// Commands validate their parameters, so the schema is kept only to describe the action
+		c.paramsSchemas[command.Name()] = awsserv.CommandSchema(command)
 	}
 }
 
@@ -0,0 +1,57 @@
+package container
+
+import (
+	"encoding/json"
+
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Serialized error returned from lambda actions as the invocation error.
+It preserves error code, status, category, details, correlation id and cause,
+so LambdaClient is able to restore the original ApplicationError on the calling side.
+
+### Example ###
+
+    {"error": {"type": "", "category": "NotFound", "status": 404, "code": "NOT_FOUND", "message": "..."}}
+*/
+type ErrorEnvelope struct {
+	// Description of the occured error
+	Error *cerr.ErrorDescription `json:"error"`
+}
+
+// NewErrorEnvelope creates a new envelope from an error.
+//   - correlationId     (optional) transaction id to trace execution through call chain.
+//   - err               an occured error.
+func NewErrorEnvelope(correlationId string, err error) *ErrorEnvelope {
+	description := cerr.ErrorDescriptionFactory.Create(err)
+	if description.CorrelationId == "" {
+		description.CorrelationId = correlationId
+	}
+	return &ErrorEnvelope{Error: description}
+}
+
+// ToJson serializes the envelope into JSON string.
+func (c *ErrorEnvelope) ToJson() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "{\"error\":{\"category\":\"Unknown\",\"status\":500,\"code\":\"UNKNOWN\",\"message\":\"Unknown error\"}}"
+	}
+	return string(data)
+}
+
+// ToError converts the envelope into an error with the serialized envelope as its message.
+// When it is returned from the handler the invocation fails, and LambdaClient restores
+// the original ApplicationError from the error message.
+func (c *ErrorEnvelope) ToError() error {
+	return &envelopeError{envelope: c}
+}
+
+// Error that fails lambda invocation and carries serialized ErrorEnvelope in its message.
+type envelopeError struct {
+	envelope *ErrorEnvelope
+}
+
+func (c *envelopeError) Error() string {
+	return c.envelope.ToJson()
+}
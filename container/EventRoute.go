@@ -0,0 +1,133 @@
+package container
+
+import (
+	"strings"
+
+	awsserv "github.com/pip-services3-go/pip-services3-aws-go/services"
+)
+
+// Types of event sources that routes are registered for
+const (
+	EventSourceSns      = "aws:sns"
+	EventSourceEvents   = "aws:events"
+	EventSourceKinesis  = "aws:kinesis"
+	EventSourceDynamoDb = "aws:dynamodb"
+	EventSourceS3       = awsserv.EventSourceS3
+)
+
+/*
+Maps an event source to an action registered in a lambda function.
+It is used to route events that do not carry "cmd" parameter,
+like SNS notifications, EventBridge events and CloudWatch scheduled events.
+
+Source can be an SNS topic ARN, an EventBridge event source,
+a scheduled rule name or ARN, a Kinesis stream ARN, a DynamoDB stream or table ARN,
+or an S3 bucket name or ARN. "*" matches any source. Detail type can be an EventBridge detail type,
+a DynamoDB Streams event name or an S3 event name pattern, like "ObjectCreated:*".
+S3 routes can be narrowed by object key prefix and suffix.
+
+Every route is tagged with the type of event source, like "aws:sns", so routes registered
+for one kind of events never match events of another kind. The type is detected from ARN
+in the source. Other sources are EventBridge sources or rule names. Routes with "*" source
+match SNS, EventBridge and stream events, but not S3 notifications.
+
+### Example ###
+
+    route := NewEventRoute("aws.events", "Scheduled Event", "cleanup")
+
+    ok := route.Match("aws.events", "Scheduled Event")  // Result: true
+*/
+type EventRoute struct {
+	// Type of the event source, like "aws:sns". Empty type matches all but S3 events.
+	EventSource string
+	// SNS topic ARN, EventBridge event source, scheduled rule name, or stream ARN
+	Source string
+	// EventBridge event detail type or DynamoDB Streams event name (optional)
+	DetailType string
+	// S3 object key prefix (optional)
+	Prefix string
+	// S3 object key suffix (optional)
+	Suffix string
+	// Command of the action to be called
+	Cmd string
+}
+
+// NewEventRoute creates a new instance of the route.
+//   - source        an SNS topic ARN, EventBridge event source, scheduled rule name, or stream ARN
+//   - detailType    (optional) an EventBridge event detail type or DynamoDB Streams event name
+//   - cmd           a command of the action to be called.
+func NewEventRoute(source string, detailType string, cmd string) *EventRoute {
+	return &EventRoute{
+		EventSource: detectEventSource(source),
+		Source:      source,
+		DetailType:  detailType,
+		Cmd:         cmd,
+	}
+}
+
+// Detects type of the event source from the source ARN.
+func detectEventSource(source string) string {
+	if source == "*" {
+		return ""
+	}
+	if !strings.HasPrefix(source, "arn:") {
+		return EventSourceEvents
+	}
+
+	tokens := strings.SplitN(source, ":", 4)
+	if len(tokens) < 3 {
+		return EventSourceEvents
+	}
+	switch tokens[2] {
+	case "sns":
+		return EventSourceSns
+	case "kinesis":
+		return EventSourceKinesis
+	case "dynamodb":
+		return EventSourceDynamoDb
+	case "s3":
+		return EventSourceS3
+	}
+	// Scheduled rules and resources of EventBridge events
+	return EventSourceEvents
+}
+
+// MatchEventSource checks if the route is registered for the type of event source.
+//   - eventSource   a type of the event source, like "aws:sns".
+// Returns true if the route matches.
+func (c *EventRoute) MatchEventSource(eventSource string) bool {
+	if c.EventSource == "" {
+		return eventSource != EventSourceS3
+	}
+	return c.EventSource == eventSource
+}
+
+// Match checks if the route matches event source and detail type.
+//   - source        an event source.
+//   - detailType    (optional) an event detail type.
+// Returns true if the route matches.
+func (c *EventRoute) Match(source string, detailType string) bool {
+	if c.Source != "*" && c.Source != source {
+		// Scheduled rules can be set by name instead of ARN,
+		// and DynamoDB streams by table ARN
+		if !strings.HasSuffix(source, ":rule/"+c.Source) && !strings.HasPrefix(source, c.Source+"/stream/") {
+			return false
+		}
+	}
+	if c.DetailType == "" || c.DetailType == detailType {
+		return true
+	}
+	// Event name patterns, like "ObjectCreated:*"
+	pattern := strings.TrimPrefix(c.DetailType, "s3:")
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(detailType, pattern[:len(pattern)-1])
+	}
+	return pattern == detailType
+}
+
+// MatchKey checks if S3 object key matches the route prefix and suffix.
+//   - key       an object key.
+// Returns true if the key matches.
+func (c *EventRoute) MatchKey(key string) bool {
+	return strings.HasPrefix(key, c.Prefix) && strings.HasSuffix(key, c.Suffix)
+}
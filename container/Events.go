@@ -0,0 +1,48 @@
+package container
+
+import (
+	"encoding/json"
+)
+
+// Identifies a failed record in a batch of records received from an event source.
+type BatchItemFailure struct {
+	// Message id or sequence number of the failed record
+	ItemIdentifier string `json:"itemIdentifier"`
+}
+
+// Partial batch response returned to SQS, Kinesis and DynamoDB Streams event sources.
+// Only failed records are retried by the event source.
+type BatchResponse struct {
+	// The list of failed records
+	BatchItemFailures []BatchItemFailure `json:"batchItemFailures"`
+}
+
+// Gets event source of the first record in "Records" array, like "aws:sqs".
+// Returns an empty string when the event has no records.
+func getRecordsEventSource(event map[string]interface{}) string {
+	records, ok := event["Records"].([]interface{})
+	if !ok || len(records) == 0 {
+		return ""
+	}
+
+	record, ok := records[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	if source, ok := record["eventSource"].(string); ok {
+		return source
+	}
+	// SNS records use capitalized keys
+	source, _ := record["EventSource"].(string)
+	return source
+}
+
+// Converts a raw event into a typed event structure.
+func convertEvent(event interface{}, result interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
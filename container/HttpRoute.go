@@ -0,0 +1,99 @@
+package container
+
+import (
+	"strings"
+)
+
+/*
+Maps HTTP method and route template to an action registered in a lambda function.
+It is used to route API Gateway proxy requests to actions.
+
+Route templates use API Gateway syntax, where path parameters are defined
+in curly braces, like "/dummies/{dummy_id}". Greedy parameters, like "{proxy+}",
+match the rest of the path.
+
+### Example ###
+
+    route := NewHttpRoute("GET", "/dummies/{dummy_id}", "get_dummy_by_id")
+
+    params, ok := route.Match("GET", "/dummies/123")  // Result: map[dummy_id:123], true
+*/
+type HttpRoute struct {
+	// HTTP method. "*" or "ANY" matches all methods.
+	Method string
+	// Route template
+	Route string
+	// Command of the action to be called
+	Cmd string
+
+	segments []string
+}
+
+// NewHttpRoute creates a new instance of the route.
+//   - method    an HTTP method. "*" or "ANY" matches all methods.
+//   - route     a route template.
+//   - cmd       a command of the action to be called.
+func NewHttpRoute(method string, route string, cmd string) *HttpRoute {
+	return &HttpRoute{
+		Method:   strings.ToUpper(method),
+		Route:    route,
+		Cmd:      cmd,
+		segments: splitPath(route),
+	}
+}
+
+// Match checks if the route matches HTTP method and path
+// and extracts path parameters.
+//   - method    an HTTP method.
+//   - path      a requested path.
+// Returns extracted path parameters and true if the route matches.
+func (c *HttpRoute) Match(method string, path string) (map[string]string, bool) {
+	if !c.MatchMethod(method) {
+		return nil, false
+	}
+
+	tokens := splitPath(path)
+	params := make(map[string]string)
+
+	for index, segment := range c.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "+}") {
+			if index >= len(tokens) {
+				return nil, false
+			}
+			params[segment[1:len(segment)-2]] = strings.Join(tokens[index:], "/")
+			return params, true
+		}
+
+		if index >= len(tokens) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[segment[1:len(segment)-1]] = tokens[index]
+		} else if segment != tokens[index] {
+			return nil, false
+		}
+	}
+
+	if len(tokens) != len(c.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// MatchMethod checks if the route accepts HTTP method.
+//   - method    an HTTP method.
+// Returns true if the method is accepted.
+func (c *HttpRoute) MatchMethod(method string) bool {
+	return c.Method == "" || c.Method == "*" || c.Method == "ANY" || c.Method == strings.ToUpper(method)
+}
+
+func splitPath(path string) []string {
+	tokens := make([]string, 0)
+	for _, token := range strings.Split(path, "/") {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
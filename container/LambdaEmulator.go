@@ -0,0 +1,253 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+const lambdaInvokePath = "/2015-03-31/functions/"
+
+/*
+Local emulator of AWS Lambda Invoke API that runs LambdaFunction
+behind HTTP server. It lets LambdaClient call the function without
+deployment to AWS by setting "connection.endpoint" to the emulator endpoint.
+
+The emulator accepts "POST /2015-03-31/functions/{name}/invocations" requests
+and supports "RequestResponse", "Event" and "DryRun" invocation types
+set in X-Amz-Invocation-Type header. The requested qualifier is returned
+as executed version. Errors returned by the function
+are reported with X-Amz-Function-Error header as AWS Lambda does.
+
+### Configuration parameters ###
+
+ - connection:
+     - host:                        (optional) host name to listen (default: localhost)
+     - port:                        (optional) port to listen, 0 to select a free port (default: 9001)
+ - options:
+     - timeout:                     (optional) invocation timeout in milliseconds (default: 30 sec)
+
+### Example ###
+
+    function := NewMyLambdaFunction()
+    ...
+    function.Open("123")
+
+    emulator := NewLambdaEmulator(function.LambdaFunction)
+    emulator.Configure(NewConfigParamsFromTuples(
+        "connection.port", 9001,
+    ))
+    emulator.Open("123")
+
+    client := NewMyLambdaClient()
+    client.Configure(NewConfigParamsFromTuples(
+        "connection.arn", "arn:aws:lambda:us-east-1:000000000000:function:my_function",
+        "connection.endpoint", emulator.GetEndpoint(),
+        "credential.access_id", "test",
+        "credential.access_key", "test",
+    ))
+*/
+type LambdaEmulator struct {
+	function *LambdaFunction
+	host     string
+	port     int
+	timeout  int
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewLambdaEmulator creates a new instance of the emulator.
+//   - function    a lambda function to be invoked.
+func NewLambdaEmulator(function *LambdaFunction) *LambdaEmulator {
+	return &LambdaEmulator{
+		function: function,
+		host:     "localhost",
+		port:     9001,
+		timeout:  30000,
+	}
+}
+
+// Configures component by passing configuration parameters.
+//   - config    configuration parameters to be set.
+func (c *LambdaEmulator) Configure(config *cconf.ConfigParams) {
+	c.host = config.GetAsStringWithDefault("connection.host", c.host)
+	c.port = config.GetAsIntegerWithDefault("connection.port", c.port)
+	c.timeout = config.GetAsIntegerWithDefault("options.timeout", c.timeout)
+}
+
+//  Checks if the component is opened.
+//  Returns true if the component has been opened and false otherwise.
+func (c *LambdaEmulator) IsOpen() bool {
+	return c.server != nil
+}
+
+// Opens the component and starts listening for invocations.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - Return 			 error or nil no errors occured.
+func (c *LambdaEmulator) Open(correlationId string) error {
+	if c.IsOpen() {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(c.host, strconv.Itoa(c.port)))
+	if err != nil {
+		return cerr.NewConnectionError(
+			correlationId,
+			"CANNOT_LISTEN",
+			"Failed to start lambda emulator").
+			WithDetails("host", c.host).
+			WithDetails("port", c.port).
+			WithCause(err)
+	}
+
+	c.listener = listener
+	c.server = &http.Server{Handler: http.HandlerFunc(c.handleRequest)}
+	go c.server.Serve(listener)
+
+	c.function.Logger().Debug(correlationId, "Lambda emulator is listening at %s", c.GetEndpoint())
+	return nil
+}
+
+// Closes component and stops listening for invocations.
+//   - correlationId 	(optional) transaction id to trace execution through call chain.
+//   - Returns 			 error or null no errors occured.
+func (c *LambdaEmulator) Close(correlationId string) error {
+	if !c.IsOpen() {
+		return nil
+	}
+
+	err := c.server.Close()
+	c.server = nil
+	c.listener = nil
+	return err
+}
+
+// Gets the endpoint of the opened emulator, like "http://localhost:9001".
+// Returns the endpoint or empty string when the emulator is closed.
+func (c *LambdaEmulator) GetEndpoint() string {
+	if c.listener == nil {
+		return ""
+	}
+	return "http://" + c.listener.Addr().String()
+}
+
+func (c *LambdaEmulator) writeServiceError(w http.ResponseWriter, status int, errorType string, message string) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"Type":    "User",
+		"message": message,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Amzn-Errortype", errorType)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func (c *LambdaEmulator) handleRequest(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	if !strings.HasPrefix(path, lambdaInvokePath) || !strings.HasSuffix(path, "/invocations") {
+		c.writeServiceError(w, http.StatusNotFound, "ResourceNotFoundException", "Resource "+path+" was not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		c.writeServiceError(w, http.StatusMethodNotAllowed, "InvalidParameterValueException", "Method "+r.Method+" is not allowed")
+		return
+	}
+
+	functionName, _ := url.PathUnescape(strings.TrimSuffix(strings.TrimPrefix(path, lambdaInvokePath), "/invocations"))
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		c.writeServiceError(w, http.StatusBadRequest, "InvalidRequestContentException", "Failed to read request payload")
+		return
+	}
+
+	event := make(map[string]interface{})
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &event); err != nil {
+			c.writeServiceError(w, http.StatusBadRequest, "InvalidRequestContentException", "Could not parse request body into json")
+			return
+		}
+	}
+
+	// Qualifier can be set in query or appended to the function name
+	qualifier := r.URL.Query().Get("Qualifier")
+	if arn, err := awscon.ParseAwsArn("", functionName); err == nil && qualifier == "" {
+		qualifier = arn.Qualifier
+	}
+	if qualifier == "" {
+		qualifier = "$LATEST"
+	}
+
+	requestId := cdata.IdGenerator.NextLong()
+	w.Header().Set("X-Amzn-Requestid", requestId)
+	w.Header().Set("X-Amz-Executed-Version", qualifier)
+
+	invocationType := r.Header.Get("X-Amz-Invocation-Type")
+	switch invocationType {
+	case "", "RequestResponse":
+		c.invoke(w, functionName, requestId, event)
+	case "Event":
+		go c.execute(functionName, requestId, event)
+		w.WriteHeader(http.StatusAccepted)
+	case "DryRun":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		c.writeServiceError(w, http.StatusBadRequest, "InvalidParameterValueException", "Invocation type "+invocationType+" is not supported")
+	}
+}
+
+func (c *LambdaEmulator) execute(functionName string, requestId string, event map[string]interface{}) (interface{}, error) {
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID:       requestId,
+		InvokedFunctionArn: functionName,
+	})
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.timeout)*time.Millisecond)
+	defer cancel()
+
+	return c.function.EventHandler(ctx, event)
+}
+
+func (c *LambdaEmulator) invoke(w http.ResponseWriter, functionName string, requestId string, event map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	result, err := c.execute(functionName, requestId, event)
+	if err != nil {
+		// Compose error payload in the same way as AWS Lambda Go runtime
+		errorType := reflect.TypeOf(err)
+		if errorType.Kind() == reflect.Ptr {
+			errorType = errorType.Elem()
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"errorMessage": err.Error(),
+			"errorType":    errorType.Name(),
+		})
+		w.Header().Set("X-Amz-Function-Error", "Unhandled")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+		return
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		body, _ = json.Marshal(map[string]interface{}{
+			"errorMessage": err.Error(),
+			"errorType":    "MarshalerError",
+		})
+		w.Header().Set("X-Amz-Function-Error", "Unhandled")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -3,9 +3,14 @@ package container
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"sort"
 
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
 	awsserv "github.com/pip-services3-go/pip-services3-aws-go/services"
 	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
 	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
@@ -18,12 +23,39 @@ import (
 	rpcserv "github.com/pip-services3-go/pip-services3-rpc-go/services"
 )
 
+// Reserved command that returns descriptions of all registered actions
+const ActionsCommand = "_actions"
+
 /*
 Abstract AWS Lambda function, that acts as a container to instantiate and run components
 and expose them via external entry point.
 
 When handling calls "cmd" parameter determines which what action shall be called, while
 other parameters are passed to the action itself.
+Failed actions fail the invocation with ErrorEnvelope holding serialized ApplicationError
+as the error message to let clients restore the original error.
+
+API Gateway proxy events (payload formats 1.0 and 2.0, including Lambda Function URLs)
+are routed to actions by HTTP method and path using routes registered via RegisterRoute. Path, query and body parameters are merged into action parameters,
+and results are returned as API Gateway responses with status codes taken from ApplicationError.
+
+SQS messages are expected to carry action parameters with "cmd" parameter in their bodies.
+Each message is executed separately and failed messages are reported as partial batch failures.
+
+SNS notifications, EventBridge events and CloudWatch scheduled events are mapped to actions
+by event routes registered via RegisterEventRoute or configured in lambda services.
+Event payload is passed to the action as parameters.
+
+DynamoDB Streams and Kinesis records are executed one by one. DynamoDB records are routed
+by stream or table ARN and event name, with "keys", "new_image" and "old_image" parameters
+converted from attribute values. Kinesis records are decoded and executed by "cmd" parameter
+or routed by stream ARN. The first failed record is reported as partial batch failure.
+
+S3 event notifications are routed by bucket, event name and object key prefix and suffix
+using routes registered via RegisterS3EventRoute or configured in lambda services.
+
+When S3ClaimCheck component is configured, requests passed by "claim_check" reference
+are read from S3 and results above the threshold are stored in S3 and returned by reference.
 
 Container configuration for this Lambda function is stored in "./config/config.yml" file.
 But this path can be overriden by CONFIG_PATH environment variable.
@@ -46,6 +78,10 @@ But this path can be overriden by CONFIG_PATH environment variable.
  - \*:counters:\*:\*:1.0          (optional) ICounters components to pass collected measurements
  - \*:discovery:\*:\*:1.0         (optional) IDiscovery services to resolve connection
  - \*:credential-store:\*:\*:1.0  (optional) Credential stores to resolve credentials
+ - \*:claim-check:s3:\*:1.0      (optional) S3ClaimCheck to pass large payloads via S3
+
+The function responds to reserved "_actions" command with the list of registered actions,
+their owning services and JSON descriptions of their parameter schemas.
 
 See LambdaClient
 
@@ -96,10 +132,20 @@ type LambdaFunction struct {
 	DependencyResolver *cref.DependencyResolver
 	// The map of registred validation schemas
 	schemas map[string]*cvalid.Schema
+	// The map of schemas that describe parameters of registered actions.
+	paramsSchemas map[string]cvalid.ISchema
 	// The map of registered actions.
-	actions map[string]func(map[string]interface{}) (interface{}, error)
+	actions map[string]func(context.Context, map[string]interface{}) (interface{}, error)
+	// The map of services that own registered actions.
+	actionServices map[string]string
+	// The list of registered HTTP routes.
+	routes []*HttpRoute
+	// The list of registered event routes.
+	eventRoutes []*EventRoute
 	// The default path to config file
 	configPath string
+	// The claim check to pass large payloads via S3.
+	claimCheck *awscon.S3ClaimCheck
 }
 
 /*
@@ -113,7 +159,11 @@ func InheriteLambdaFunction(overrides ILambdaFunctionOverrides, name string, des
 		tracer:             ctrace.NewCompositeTracer(nil),
 		DependencyResolver: cref.NewDependencyResolver(),
 		schemas:            make(map[string]*cvalid.Schema, 0),
-		actions:            make(map[string]func(map[string]interface{}) (interface{}, error), 0),
+		paramsSchemas:      make(map[string]cvalid.ISchema, 0),
+		actions:            make(map[string]func(context.Context, map[string]interface{}) (interface{}, error), 0),
+		actionServices:     make(map[string]string, 0),
+		routes:             make([]*HttpRoute, 0),
+		eventRoutes:        make([]*EventRoute, 0),
 		configPath:         "./config/config.yml",
 		Overrides:          overrides,
 	}
@@ -146,7 +196,7 @@ func (c *LambdaFunction) captureErrors(correlationId string) {
 func (c *LambdaFunction) captureExit(correlationId string) {
 	c.Logger().Info(correlationId, "Press Control-C to stop the microservice...")
 
-	ch := make(chan os.Signal)
+	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt)
 
 	go func() {
@@ -167,6 +217,13 @@ func (c *LambdaFunction) SetReferences(references cref.IReferences) {
 	c.references = references
 	c.counters.SetReferences(references)
 	c.DependencyResolver.SetReferences(references)
+
+	ref := references.GetOneOptional(
+		cref.NewDescriptor("*", "claim-check", "s3", "*", "1.0"))
+	if claimCheck, ok := ref.(*awscon.S3ClaimCheck); ok {
+		c.claimCheck = claimCheck
+	}
+
 	c.Overrides.Register()
 }
 
@@ -217,7 +274,7 @@ func (c *LambdaFunction) Run() error {
 	parameters := c.getParameters()
 	c.ReadConfigFromFile(correlationId, path, parameters)
 
-	c.captureErrors(correlationId)
+	defer c.captureErrors(correlationId)
 	c.captureExit(correlationId)
 	return c.Open(correlationId)
 }
@@ -272,7 +329,26 @@ func (c *LambdaFunction) RegisterServices() {
 		actions := service.GetActions()
 		for _, action := range actions {
 			c.Logger().Debug("RegisterServices", "Register commmand: %v", action.Cmd)
-			c.RegisterAction(action.Cmd, action.Schema, action.Action)
+			if action.ActionWithContext != nil {
+				c.RegisterActionWithContext(action.Cmd, action.Schema, action.ActionWithContext)
+			} else {
+				c.RegisterAction(action.Cmd, action.Schema, action.Action)
+			}
+			c.actionServices[action.Cmd] = action.Service
+			if action.ParamsSchema != nil {
+				c.paramsSchemas[action.Cmd] = action.ParamsSchema
+			}
+			if action.Route != "" {
+				c.RegisterRoute(action.Method, action.Route, action.Cmd)
+			}
+			for _, event := range action.Events {
+				if event.EventSource == EventSourceS3 || event.Prefix != "" || event.Suffix != "" {
+					c.RegisterS3EventRoute(event.Source, event.DetailType, event.Prefix, event.Suffix, action.Cmd)
+				} else if err := c.RegisterEventRoute(event.Source, event.DetailType, action.Cmd); err == nil && event.EventSource != "" {
+					// Configured type of event source overrides the detected one
+					c.eventRoutes[len(c.eventRoutes)-1].EventSource = event.EventSource
+				}
+			}
 		}
 	}
 }
@@ -294,25 +370,229 @@ func (c *LambdaFunction) RegisterAction(cmd string, schema *cvalid.Schema,
 		return cerr.NewUnknownError("", "NO_ACTION", "Missing action")
 	}
 
+	return c.RegisterActionWithContext(cmd, schema,
+		func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return action(params)
+		})
+}
+
+/*
+Registers an action in this lambda function that receives invocation context.
+The context carries invocation deadline, cancellation and lambda context
+with AWS request id, that can be retrieved by lambdacontext.FromContext.
+   - cmd           a action/command name.
+   - schema        a validation schema to validate received parameters.
+   - action        an action function that is called when action is invoked.
+*/
+func (c *LambdaFunction) RegisterActionWithContext(cmd string, schema *cvalid.Schema,
+	action func(ctx context.Context, params map[string]interface{}) (result interface{}, err error)) error {
+
+	if cmd == "" {
+		return cerr.NewUnknownError("", "NO_COMMAND", "Missing command")
+	}
+
+	if action == nil {
+		return cerr.NewUnknownError("", "NO_ACTION", "Missing action")
+	}
+
+	if cmd == ActionsCommand {
+		return cerr.NewUnknownError("", "RESERVED_COMMAND", "Command "+cmd+" is reserved").
+			WithDetails("command", cmd)
+	}
+
 	// Hack!!! Wrapping action to preserve prototyping context
-	actionCurl := func(params map[string]interface{}) (interface{}, error) {
+	actionCurl := func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 		// Perform validation
 		if schema != nil {
-			correlationId, _ := params["correlaton_id"].(string)
+			correlationId, _ := params["correlation_id"].(string)
 			err := schema.ValidateAndReturnError(correlationId, params, false)
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		return action(params)
+		return action(ctx, params)
 	}
 
 	c.actions[cmd] = actionCurl
+	c.schemas[cmd] = schema
 	return nil
 }
 
-func (c *LambdaFunction) execute(ctx context.Context, params map[string]interface{}) (string, error) {
+/*
+Registers an action in this lambda function with a schema that also describes
+action parameters in "_actions" command and generated OpenAPI documents.
+   - cmd           a action/command name.
+   - schema        a schema to validate and describe received parameters, like *cvalid.ObjectSchema.
+   - action        an action function that is called when action is invoked.
+*/
+func (c *LambdaFunction) RegisterActionWithParamsSchema(cmd string, schema cvalid.ISchema,
+	action func(params map[string]interface{}) (result interface{}, err error)) error {
+
+	if action == nil {
+		return cerr.NewUnknownError("", "NO_ACTION", "Missing action")
+	}
+
+	err := c.RegisterActionWithContext(cmd, nil,
+		func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			if schema != nil {
+				correlationId, _ := params["correlation_id"].(string)
+				err := schema.ValidateAndReturnError(correlationId, params, false)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return action(params)
+		})
+	if err != nil {
+		return err
+	}
+
+	c.paramsSchemas[cmd] = schema
+	return nil
+}
+
+/*
+Gets all actions registered in this lambda function, sorted by command.
+Actions contain command, owning service, validation schema and HTTP route and events
+they are exposed with.
+Returns a list of registered actions.
+*/
+func (c *LambdaFunction) GetActions() []*awsserv.LambdaAction {
+	cmds := make([]string, 0, len(c.actions))
+	for cmd := range c.actions {
+		cmds = append(cmds, cmd)
+	}
+	sort.Strings(cmds)
+
+	actions := make([]*awsserv.LambdaAction, 0, len(cmds))
+	for _, cmd := range cmds {
+		actionWithContext := c.actions[cmd]
+		action := &awsserv.LambdaAction{
+			Cmd:          cmd,
+			Service:      c.actionServices[cmd],
+			Schema:       c.schemas[cmd],
+			ParamsSchema: c.paramsSchemas[cmd],
+			Action: func(params map[string]interface{}) (interface{}, error) {
+				return actionWithContext(context.Background(), params)
+			},
+			ActionWithContext: actionWithContext,
+			Events:            make([]*awsserv.LambdaEventSource, 0),
+		}
+		for _, route := range c.routes {
+			if route.Cmd == cmd {
+				action.Method = route.Method
+				action.Route = route.Route
+				break
+			}
+		}
+		for _, route := range c.eventRoutes {
+			if route.Cmd == cmd {
+				action.Events = append(action.Events, &awsserv.LambdaEventSource{
+					EventSource: route.EventSource,
+					Source:      route.Source,
+					DetailType:  route.DetailType,
+					Prefix:      route.Prefix,
+					Suffix:      route.Suffix,
+				})
+			}
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// Describes registered actions for the reserved discovery command.
+func (c *LambdaFunction) describeActions() []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	for _, action := range c.GetActions() {
+		description := map[string]interface{}{
+			"cmd":     action.Cmd,
+			"service": action.Service,
+			"schema":  awsserv.DescribeActionSchema(action),
+		}
+		if action.Route != "" {
+			description["method"] = action.Method
+			description["route"] = action.Route
+		}
+		if len(action.Events) > 0 {
+			events := make([]map[string]interface{}, 0, len(action.Events))
+			for _, event := range action.Events {
+				events = append(events, map[string]interface{}{
+					"event_source": event.EventSource,
+					"source":       event.Source,
+					"detail_type":  event.DetailType,
+					"prefix":       event.Prefix,
+					"suffix":       event.Suffix,
+				})
+			}
+			description["events"] = events
+		}
+		result = append(result, description)
+	}
+	return result
+}
+
+/*
+Registers an HTTP route to expose an action via API Gateway.
+   - method        an HTTP method. "*" or "ANY" matches all methods.
+   - route         a route template, like "/dummies/{dummy_id}".
+   - cmd           a command of the registered action.
+*/
+func (c *LambdaFunction) RegisterRoute(method string, route string, cmd string) error {
+	if cmd == "" {
+		return cerr.NewUnknownError("", "NO_COMMAND", "Missing command")
+	}
+
+	if route == "" {
+		return cerr.NewUnknownError("", "NO_ROUTE", "Missing route")
+	}
+
+	c.routes = append(c.routes, NewHttpRoute(method, route, cmd))
+	return nil
+}
+
+/*
+Registers an event route to trigger an action by events without "cmd" parameter.
+   - source        an SNS topic ARN, EventBridge event source, scheduled rule name, or stream ARN. "*" matches any source.
+   - detailType    (optional) an EventBridge event detail type or DynamoDB Streams event name.
+   - cmd           a command of the registered action.
+*/
+func (c *LambdaFunction) RegisterEventRoute(source string, detailType string, cmd string) error {
+	if cmd == "" {
+		return cerr.NewUnknownError("", "NO_COMMAND", "Missing command")
+	}
+
+	if source == "" {
+		return cerr.NewUnknownError("", "NO_SOURCE", "Missing event source")
+	}
+
+	c.eventRoutes = append(c.eventRoutes, NewEventRoute(source, detailType, cmd))
+	return nil
+}
+
+/*
+Registers an event route to trigger an action by S3 event notifications.
+   - bucket        an S3 bucket name or ARN. "*" matches any bucket.
+   - eventName     (optional) an S3 event name or pattern, like "ObjectCreated:*".
+   - prefix        (optional) an object key prefix.
+   - suffix        (optional) an object key suffix.
+   - cmd           a command of the registered action.
+*/
+func (c *LambdaFunction) RegisterS3EventRoute(bucket string, eventName string, prefix string, suffix string, cmd string) error {
+	err := c.RegisterEventRoute(bucket, eventName, cmd)
+	if err != nil {
+		return err
+	}
+
+	route := c.eventRoutes[len(c.eventRoutes)-1]
+	route.EventSource = EventSourceS3
+	route.Prefix = prefix
+	route.Suffix = suffix
+	return nil
+}
+
+func (c *LambdaFunction) executeAction(ctx context.Context, params map[string]interface{}) (result interface{}, err error) {
 
 	cmd, ok := params["cmd"].(string)
 	correlationId, _ := params["correlation_id"].(string)
@@ -322,8 +602,11 @@ func (c *LambdaFunction) execute(ctx context.Context, params map[string]interfac
 			correlationId,
 			"NO_COMMAND",
 			"Cmd parameter is missing")
-		ctx.Done()
-		return "ERROR", err
+		return nil, err
+	}
+
+	if cmd == ActionsCommand {
+		return c.describeActions(), nil
 	}
 
 	action := c.actions[cmd]
@@ -333,23 +616,161 @@ func (c *LambdaFunction) execute(ctx context.Context, params map[string]interfac
 			"NO_ACTION",
 			"Action "+cmd+" was not found").
 			WithDetails("command", cmd)
+		return nil, err
+	}
+
+	// Panics in actions must not terminate the warm container
+	defer c.recoverAction(correlationId, cmd, &result, &err)
+
+	return action(ctx, params)
+}
+
+// Recovers a panic raised by an action and converts it into UnknownError
+// with stack trace of the panic in StackTrace. The error is logged and counted
+// in "<cmd>.exec_errors" counter.
+func (c *LambdaFunction) recoverAction(correlationId string, cmd string, result *interface{}, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	cause, ok := r.(error)
+	if !ok {
+		cause = fmt.Errorf("%v", r)
+	}
+
+	appErr := cerr.NewUnknownError(
+		correlationId,
+		"ACTION_PANIC",
+		"Action "+cmd+" failed unexpectedly: "+cause.Error()).
+		WithDetails("command", cmd).
+		WithCause(cause)
+	appErr.StackTrace = string(debug.Stack())
+
+	c.Logger().Error(correlationId, appErr, "Recovered from panic in %s action", cmd)
+	c.counters.IncrementOne(cmd + ".exec_errors")
+
+	*result = nil
+	*err = appErr
+}
+
+// Executes an action and returns its result serialized into JSON.
+// Errors are returned as ErrorEnvelope to fail the invocation and preserve their details.
+// Large requests and responses are passed via S3 when claim check is configured.
+func (c *LambdaFunction) execute(ctx context.Context, params map[string]interface{}) (string, error) {
+	correlationId, _ := params["correlation_id"].(string)
+	cmd, _ := params["cmd"].(string)
+
+	var res interface{}
+	params, err := c.readClaimCheck(ctx, correlationId, params)
+	if err == nil {
+		res, err = c.executeAction(ctx, params)
+	}
+	if err == nil {
+		var convRes []byte
+		convRes, err = json.Marshal(res)
+		if err == nil {
+			return c.writeClaimCheck(ctx, correlationId, cmd, convRes)
+		}
+	}
+
+	c.Logger().Error(correlationId, err, "Failed to execute %s action", cmd)
+	return "", NewErrorEnvelope(correlationId, err).ToError()
+}
+
+// Restores request parameters stored in S3 when they are passed by claim check reference.
+func (c *LambdaFunction) readClaimCheck(ctx context.Context, correlationId string,
+	params map[string]interface{}) (map[string]interface{}, error) {
+
+	reference := awscon.ParseClaimCheckReference(params)
+	if reference == nil {
+		return params, nil
+	}
+
+	if c.claimCheck == nil || !c.claimCheck.IsEnabled() {
+		return nil, cerr.NewBadRequestError(
+			correlationId,
+			"NO_CLAIM_CHECK",
+			"Request was passed via S3, but claim check is not configured").
+			WithDetails("bucket", reference.Bucket).
+			WithDetails("key", reference.Key)
+	}
+
+	payload, err := c.claimCheck.Get(ctx, correlationId, reference)
+	if err != nil {
+		return nil, err
+	}
 
-		ctx.Done()
-		return "ERROR", err
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, cerr.NewBadRequestError(
+			correlationId,
+			"INVALID_CLAIM_CHECK",
+			"Request stored in S3 is not a valid JSON object").
+			WithCause(err)
 	}
+	return result, nil
+}
 
-	res, err := action(params)
-	ctx.Done()
-	resStr := "ERROR"
-	if res != nil {
-		convRes, convErr := json.Marshal(res)
-		if convRes == nil || convErr != nil {
-			err = convErr
-		} else {
-			resStr = (string)(convRes)
+// Stores the result in S3 and returns claim check reference instead
+// when the result exceeds configured threshold.
+func (c *LambdaFunction) writeClaimCheck(ctx context.Context, correlationId string, cmd string,
+	result []byte) (string, error) {
+
+	if c.claimCheck == nil || !c.claimCheck.NeedsOffload(len(result)) {
+		if len(result) > awscon.LambdaMaxSyncPayloadSize {
+			err := cerr.NewBadRequestError(
+				correlationId,
+				"PAYLOAD_TOO_LARGE",
+				"Result of "+cmd+" action exceeds maximum lambda payload size").
+				WithStatus(http.StatusRequestEntityTooLarge).
+				WithDetails("size", len(result))
+			c.Logger().Error(correlationId, err, "Failed to execute %s action", cmd)
+			return "", NewErrorEnvelope(correlationId, err).ToError()
 		}
+		return (string)(result), nil
+	}
+
+	reference, err := c.claimCheck.Put(ctx, correlationId, result)
+	if err != nil {
+		c.Logger().Error(correlationId, err, "Failed to execute %s action", cmd)
+		return "", NewErrorEnvelope(correlationId, err).ToError()
+	}
+
+	c.Logger().Trace(correlationId, "Result of %s action was stored in S3 as %s", cmd, reference.Key)
+	envelope, _ := json.Marshal(map[string]interface{}{awscon.ClaimCheckParam: reference})
+	return (string)(envelope), nil
+}
+
+// Routes the event to the handler of its kind.
+// Events of unknown kinds are executed as direct action calls.
+func (c *LambdaFunction) dispatch(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	if isApiGatewayV2HttpEvent(event) {
+		return c.handleApiGatewayV2Http(ctx, event)
+	}
+
+	if isApiGatewayProxyEvent(event) {
+		return c.handleApiGatewayProxy(ctx, event)
+	}
+
+	if isCloudWatchEvent(event) {
+		return c.handleCloudWatchEvent(ctx, event)
 	}
-	return resStr, err
+
+	switch getRecordsEventSource(event) {
+	case "aws:sqs":
+		return c.handleSqsEvent(ctx, event)
+	case "aws:sns":
+		return c.handleSnsEvent(ctx, event)
+	case "aws:dynamodb":
+		return c.handleDynamoDBEvent(ctx, event)
+	case "aws:kinesis":
+		return c.handleKinesisEvent(ctx, event)
+	case "aws:s3":
+		return c.handleS3Event(ctx, event)
+	}
+
+	return c.execute(ctx, event)
 }
 
 func (c *LambdaFunction) Handler(ctx context.Context, event map[string]interface{}) (string, error) { //handler(event: any, context: any) {
@@ -361,7 +782,6 @@ func (c *LambdaFunction) Handler(ctx context.Context, event map[string]interface
 	} else { // Start before execute
 		err := c.Run()
 		if err != nil {
-			ctx.Done()
 			return "", err
 		}
 		if event != nil {
@@ -390,11 +810,50 @@ func (c *LambdaFunction) GetHandler() func(ctx context.Context, event map[string
 	}
 }
 
+func (c *LambdaFunction) EventHandler(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	// Direct action calls are executed as they are
+	if _, ok := event["cmd"]; ok || event == nil {
+		return c.Handler(ctx, event)
+	}
+
+	if !c.IsOpen() {
+		err := c.Run()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.dispatch(ctx, event)
+}
+
+/*
+Gets entry point into this lambda function that routes AWS events to actions.
+   - event     an incoming event object with invocation parameters.
+   - context   a context object with local references.
+
+Events with "cmd" parameter are executed as direct action calls, like in GetHandler.
+API Gateway REST and HTTP API proxy events are routed to actions using registered HTTP routes.
+SQS events are executed message by message and return partial batch failures.
+SNS, EventBridge and scheduled events are routed to actions using registered event routes.
+DynamoDB Streams and Kinesis events are executed record by record and return partial batch failures.
+S3 event notifications are routed to actions by bucket and object key.
+*/
+
+func (c *LambdaFunction) GetEventHandler() func(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+
+	// Return plugin function
+	return func(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+		// Calling run with changed context
+		return c.EventHandler(ctx, event)
+	}
+}
+
 /*
 Calls registered action in this lambda function.
 "cmd" parameter in the action parameters determin
 what action shall be called.
 
+Errors returned in ErrorEnvelope are restored and returned as ApplicationError.
+
 This method shall only be used in testing.
    - params action parameters.
    - callback callback function that receives action result or error.
@@ -402,5 +861,9 @@ This method shall only be used in testing.
 
 func (c *LambdaFunction) Act(params map[string]interface{}) (string, error) {
 	ctx := context.TODO()
-	return c.GetHandler()(ctx, params)
+	res, err := c.GetHandler()(ctx, params)
+	if envErr, ok := err.(*envelopeError); ok {
+		return "", cerr.ApplicationErrorFactory.Create(envErr.envelope.Error)
+	}
+	return res, err
 }
@@ -0,0 +1,144 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+// Checks for EventBridge and CloudWatch scheduled events
+func isCloudWatchEvent(event map[string]interface{}) bool {
+	_, hasDetailType := event["detail-type"]
+	_, hasSource := event["source"]
+	return hasDetailType && hasSource
+}
+
+// Finds a command for the event source among event routes registered for the type of event source.
+func (c *LambdaFunction) findEventRoute(eventSource string, sources []string, detailType string) string {
+	for _, route := range c.eventRoutes {
+		if !route.MatchEventSource(eventSource) {
+			continue
+		}
+		for _, source := range sources {
+			if route.Match(source, detailType) {
+				return route.Cmd
+			}
+		}
+	}
+	return ""
+}
+
+// Merges event payload into action parameters.
+// JSON objects are merged as they are, other values are passed as "message" parameter.
+func composeEventParams(payload []byte) map[string]interface{} {
+	params := make(map[string]interface{})
+
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		params["message"] = string(payload)
+	} else if values, ok := value.(map[string]interface{}); ok {
+		for key, value := range values {
+			params[key] = value
+		}
+	} else if value != nil {
+		params["message"] = value
+	}
+	return params
+}
+
+func (c *LambdaFunction) executeEventAction(ctx context.Context, name string, cmd string,
+	correlationId string, params map[string]interface{}) error {
+
+	params["cmd"] = cmd
+	if id, ok := params["correlation_id"].(string); !ok || id == "" {
+		params["correlation_id"] = correlationId
+	} else {
+		correlationId = id
+	}
+
+	timing := c.Instrument(correlationId, name+"."+cmd)
+	_, err := c.executeAction(ctx, params)
+	timing.EndTiming(err)
+	return err
+}
+
+// Executes actions mapped to SNS topics for every notification record.
+// Returns the first occured error to let SNS retry the delivery.
+func (c *LambdaFunction) handleSnsEvent(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var snsEvent events.SNSEvent
+	if err := convertEvent(event, &snsEvent); err != nil {
+		return nil, err
+	}
+
+	var globalErr error
+	for _, record := range snsEvent.Records {
+		correlationId := record.SNS.MessageID
+		if attribute, ok := record.SNS.MessageAttributes["correlation_id"].(map[string]interface{}); ok {
+			if value, ok := attribute["Value"].(string); ok && value != "" {
+				correlationId = value
+			}
+		}
+
+		cmd := c.findEventRoute(EventSourceSns, []string{record.SNS.TopicArn}, "")
+		if cmd == "" {
+			err := cerr.NewBadRequestError(
+				correlationId,
+				"NO_EVENT_ROUTE",
+				"Route for SNS topic "+record.SNS.TopicArn+" was not found").
+				WithDetails("topic_arn", record.SNS.TopicArn)
+			c.Logger().Error(correlationId, err, "Failed to process SNS notification")
+			c.counters.IncrementOne("sns.exec_errors")
+			if globalErr == nil {
+				globalErr = err
+			}
+			continue
+		}
+
+		params := composeEventParams([]byte(record.SNS.Message))
+		if record.SNS.Subject != "" {
+			params["subject"] = record.SNS.Subject
+		}
+
+		err := c.executeEventAction(ctx, "sns", cmd, correlationId, params)
+		if err != nil && globalErr == nil {
+			globalErr = err
+		}
+	}
+
+	return nil, globalErr
+}
+
+// Executes an action mapped to EventBridge event source and detail type,
+// or to a CloudWatch scheduled rule.
+func (c *LambdaFunction) handleCloudWatchEvent(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var cwEvent events.CloudWatchEvent
+	if err := convertEvent(event, &cwEvent); err != nil {
+		return nil, err
+	}
+
+	correlationId := cwEvent.ID
+	sources := append([]string{cwEvent.Source}, cwEvent.Resources...)
+
+	cmd := c.findEventRoute(EventSourceEvents, sources, cwEvent.DetailType)
+	if cmd == "" {
+		err := cerr.NewBadRequestError(
+			correlationId,
+			"NO_EVENT_ROUTE",
+			"Route for event "+cwEvent.Source+" "+cwEvent.DetailType+" was not found").
+			WithDetails("source", cwEvent.Source).
+			WithDetails("detail_type", cwEvent.DetailType)
+		c.Logger().Error(correlationId, err, "Failed to process event")
+		c.counters.IncrementOne("events.exec_errors")
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	if len(cwEvent.Detail) > 0 {
+		params = composeEventParams(cwEvent.Detail)
+	}
+
+	err := c.executeEventAction(ctx, "events", cmd, correlationId, params)
+	return nil, err
+}
@@ -0,0 +1,76 @@
+package container
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+// Finds a command for S3 object event among registered S3 event routes.
+func (c *LambdaFunction) findS3EventRoute(bucket string, bucketArn string, eventName string, key string) string {
+	for _, route := range c.eventRoutes {
+		if !route.MatchEventSource(EventSourceS3) {
+			continue
+		}
+		if (route.Match(bucket, eventName) || route.Match(bucketArn, eventName)) && route.MatchKey(key) {
+			return route.Cmd
+		}
+	}
+	return ""
+}
+
+// Executes actions mapped to S3 buckets and object keys for every notification record.
+// Returns the first occured error to let the asynchronous invocation be retried.
+func (c *LambdaFunction) handleS3Event(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var s3Event events.S3Event
+	if err := convertEvent(event, &s3Event); err != nil {
+		return nil, err
+	}
+
+	var globalErr error
+	for _, record := range s3Event.Records {
+		correlationId := record.ResponseElements["x-amz-request-id"]
+
+		// Object keys in notifications are URL encoded
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			key = record.S3.Object.Key
+		}
+
+		cmd := c.findS3EventRoute(record.S3.Bucket.Name, record.S3.Bucket.Arn, record.EventName, key)
+		if cmd == "" {
+			err := cerr.NewBadRequestError(
+				correlationId,
+				"NO_EVENT_ROUTE",
+				"Route for S3 object "+record.S3.Bucket.Name+"/"+key+" was not found").
+				WithDetails("bucket", record.S3.Bucket.Name).
+				WithDetails("key", key).
+				WithDetails("event_name", record.EventName)
+			c.Logger().Error(correlationId, err, "Failed to process S3 notification")
+			c.counters.IncrementOne("s3.exec_errors")
+			if globalErr == nil {
+				globalErr = err
+			}
+			continue
+		}
+
+		params := map[string]interface{}{
+			"event_name": record.EventName,
+			"event_time": record.EventTime,
+			"bucket":     record.S3.Bucket.Name,
+			"key":        key,
+			"size":       record.S3.Object.Size,
+			"etag":       record.S3.Object.ETag,
+			"version_id": record.S3.Object.VersionID,
+		}
+
+		err = c.executeEventAction(ctx, "s3", cmd, correlationId, params)
+		if err != nil && globalErr == nil {
+			globalErr = err
+		}
+	}
+
+	return nil, globalErr
+}
@@ -0,0 +1,66 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+// Executes an action for every SQS message.
+// Message bodies shall contain action parameters with "cmd" parameter.
+// When correlation id is missing in the body it is taken from "correlation_id" message attribute.
+// Returns partial batch response with ids of messages that failed.
+func (c *LambdaFunction) handleSqsEvent(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var sqsEvent events.SQSEvent
+	if err := convertEvent(event, &sqsEvent); err != nil {
+		return nil, err
+	}
+
+	response := BatchResponse{
+		BatchItemFailures: make([]BatchItemFailure, 0),
+	}
+
+	for _, message := range sqsEvent.Records {
+		err := c.executeSqsMessage(ctx, message)
+		if err != nil {
+			response.BatchItemFailures = append(response.BatchItemFailures,
+				BatchItemFailure{ItemIdentifier: message.MessageId})
+		}
+	}
+
+	return response, nil
+}
+
+func (c *LambdaFunction) executeSqsMessage(ctx context.Context, message events.SQSMessage) error {
+	correlationId := message.MessageId
+	if attribute, ok := message.MessageAttributes["correlation_id"]; ok && attribute.StringValue != nil {
+		correlationId = *attribute.StringValue
+	}
+
+	params := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(message.Body), &params); err != nil {
+		err = cerr.NewBadRequestError(
+			correlationId,
+			"BAD_MESSAGE",
+			"Failed to decode SQS message "+message.MessageId).
+			WithDetails("message_id", message.MessageId).
+			WithCause(err)
+		c.Logger().Error(correlationId, err, "Failed to process SQS message")
+		c.counters.IncrementOne("sqs.exec_errors")
+		return err
+	}
+
+	if id, ok := params["correlation_id"].(string); !ok || id == "" {
+		params["correlation_id"] = correlationId
+	} else {
+		correlationId = id
+	}
+
+	cmd, _ := params["cmd"].(string)
+	timing := c.Instrument(correlationId, "sqs."+cmd)
+	_, err := c.executeAction(ctx, params)
+	timing.EndTiming(err)
+	return err
+}
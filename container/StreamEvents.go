@@ -0,0 +1,163 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+// Executes an action for every DynamoDB Streams record.
+// Records are routed by event source ARN (stream or table ARN) and event name
+// (INSERT, MODIFY or REMOVE) using registered event routes.
+// Processing stops at the first failed record, which sequence number is returned
+// as partial batch failure to checkpoint the stream.
+func (c *LambdaFunction) handleDynamoDBEvent(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var dynamoEvent events.DynamoDBEvent
+	if err := convertEvent(event, &dynamoEvent); err != nil {
+		return nil, err
+	}
+
+	response := BatchResponse{
+		BatchItemFailures: make([]BatchItemFailure, 0),
+	}
+
+	for _, record := range dynamoEvent.Records {
+		correlationId := record.EventID
+
+		params := make(map[string]interface{})
+		params["event_name"] = record.EventName
+		params["sequence_number"] = record.Change.SequenceNumber
+		params["keys"] = convertAttributeValues(record.Change.Keys)
+		if record.Change.NewImage != nil {
+			params["new_image"] = convertAttributeValues(record.Change.NewImage)
+		}
+		if record.Change.OldImage != nil {
+			params["old_image"] = convertAttributeValues(record.Change.OldImage)
+		}
+
+		err := c.executeStreamRecord(ctx, "dynamodb", EventSourceDynamoDb, record.EventSourceArn, record.EventName, correlationId, params)
+		if err != nil {
+			response.BatchItemFailures = append(response.BatchItemFailures,
+				BatchItemFailure{ItemIdentifier: record.Change.SequenceNumber})
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// Executes an action for every Kinesis record.
+// When decoded record data contains "cmd" parameter it is executed as a direct action call.
+// Otherwise the record is routed by event source ARN using registered event routes.
+// Processing stops at the first failed record, which sequence number is returned
+// as partial batch failure to checkpoint the stream.
+func (c *LambdaFunction) handleKinesisEvent(ctx context.Context, event map[string]interface{}) (interface{}, error) {
+	var kinesisEvent events.KinesisEvent
+	if err := convertEvent(event, &kinesisEvent); err != nil {
+		return nil, err
+	}
+
+	response := BatchResponse{
+		BatchItemFailures: make([]BatchItemFailure, 0),
+	}
+
+	for _, record := range kinesisEvent.Records {
+		correlationId := record.EventID
+
+		params := composeEventParams(record.Kinesis.Data)
+		if _, ok := params["cmd"]; !ok {
+			params["partition_key"] = record.Kinesis.PartitionKey
+			params["sequence_number"] = record.Kinesis.SequenceNumber
+		}
+
+		err := c.executeStreamRecord(ctx, "kinesis", EventSourceKinesis, record.EventSourceArn, "", correlationId, params)
+		if err != nil {
+			response.BatchItemFailures = append(response.BatchItemFailures,
+				BatchItemFailure{ItemIdentifier: record.Kinesis.SequenceNumber})
+			break
+		}
+	}
+
+	return response, nil
+}
+
+func (c *LambdaFunction) executeStreamRecord(ctx context.Context, name string, eventSource string, sourceArn string,
+	eventName string, correlationId string, params map[string]interface{}) error {
+
+	cmd, _ := params["cmd"].(string)
+	if cmd == "" {
+		cmd = c.findEventRoute(eventSource, []string{sourceArn}, eventName)
+	}
+
+	if cmd == "" {
+		err := cerr.NewBadRequestError(
+			correlationId,
+			"NO_EVENT_ROUTE",
+			"Route for stream "+sourceArn+" was not found").
+			WithDetails("source", sourceArn).
+			WithDetails("event_name", eventName)
+		c.Logger().Error(correlationId, err, "Failed to process %s record", name)
+		c.counters.IncrementOne(name + ".exec_errors")
+		return err
+	}
+
+	return c.executeEventAction(ctx, name, cmd, correlationId, params)
+}
+
+// Converts DynamoDB attribute values into plain values.
+func convertAttributeValues(values map[string]events.DynamoDBAttributeValue) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range values {
+		result[key] = convertAttributeValue(value)
+	}
+	return result
+}
+
+func convertAttributeValue(value events.DynamoDBAttributeValue) interface{} {
+	switch value.DataType() {
+	case events.DataTypeString:
+		return value.String()
+	case events.DataTypeNumber:
+		return convertNumber(value.Number())
+	case events.DataTypeBinary:
+		return value.Binary()
+	case events.DataTypeBoolean:
+		return value.Boolean()
+	case events.DataTypeNull:
+		return nil
+	case events.DataTypeList:
+		list := value.List()
+		result := make([]interface{}, len(list))
+		for index, item := range list {
+			result[index] = convertAttributeValue(item)
+		}
+		return result
+	case events.DataTypeMap:
+		return convertAttributeValues(value.Map())
+	case events.DataTypeStringSet:
+		return value.StringSet()
+	case events.DataTypeNumberSet:
+		set := value.NumberSet()
+		result := make([]interface{}, len(set))
+		for index, item := range set {
+			result[index] = convertNumber(item)
+		}
+		return result
+	case events.DataTypeBinarySet:
+		return value.BinarySet()
+	}
+	return nil
+}
+
+func convertNumber(value string) interface{} {
+	if result, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return result
+	}
+	if result, err := strconv.ParseFloat(value, 64); err == nil {
+		return result
+	}
+	return json.Number(value)
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	awsconn "github.com/pip-services3-go/pip-services3-aws-go/connect"
@@ -24,19 +23,32 @@ import (
  - connections:
      - discovery_key:         (optional) a key to retrieve the connection from IDiscovery
      - region:                (optional) AWS region
+     - endpoint:              (optional) custom AWS service endpoint, i.e. LocalStack
+     - s3_force_path_style:   (optional) true to use path-style addressing for S3 buckets (default: false)
+     - disable_ssl:           (optional) true to disable SSL (default: false)
+     - ca_bundle:             (optional) path to custom CA bundle file in PEM format
+     - sts_endpoint:          (optional) custom STS endpoint to assume the role
  - credentials:
      - store_key:             (optional) a key to retrieve the credentials from ICredentialStore
-     - access_id:             AWS access/client id
-     - access_key:            AWS access/client id
+     - access_id:             (optional) AWS access/client id
+     - access_key:            (optional) AWS access/client key
+     - profile:               (optional) named profile in shared AWS credentials files
+     - session_token:         (optional) session token for temporary credentials
+     - role_arn:              (optional) ARN of the role to assume with STS
+     - external_id:           (optional) external id to assume the role
+     - role_session_name:     (optional) session name of the assumed role
  - options:
      - interval:              interval in milliseconds to save current counters measurements (default: 5 mins)
      - reset_timeout:         timeout in milliseconds to reset the counters. 0 disables the reset (default: 0)
+     - connect_timeout:       (optional) connection timeout in milliseconds (default: 30 sec)
+     - max_retries:           (optional) maximum number of retries for failed requests (default: 3)
 
  ### References ###
 
  - \*:context-info:\*:\*:1.0      (optional) ContextInfo to detect the context id and specify counters source
  - \*:discovery:\*:\*:1.0         (optional) IDiscovery services to resolve connections
  - \*:credential-store:\*:\*:1.0  (optional) Credential stores to resolve credentials
+ - \*:session-provider:aws:\*:1.0 (optional) Shared AwsSessionProvider to create AWS sessions
 
  See Counter (in the Pip.Services components package)
  See CachedCounters (in the Pip.Services components package)
@@ -71,9 +83,9 @@ type CloudWatchCounters struct {
 	ccount.CachedCounters
 	logger *clog.CompositeLogger
 
-	connectionResolver *awsconn.AwsConnectionResolver
+	sessionProvider    *awsconn.AwsSessionProvider
+	ownSessionProvider bool
 	connection         *awsconn.AwsConnectionParams
-	connectTimeout     int
 	client             *cloudwatch.CloudWatch //AmazonCloudWatchClient
 	source             string
 	instance           string
@@ -84,8 +96,8 @@ type CloudWatchCounters struct {
 func NewCloudWatchCounters() *CloudWatchCounters {
 	c := &CloudWatchCounters{
 		logger:             clog.NewCompositeLogger(),
-		connectionResolver: awsconn.NewAwsConnectionResolver(),
-		connectTimeout:     30000,
+		sessionProvider:    awsconn.NewAwsSessionProvider(),
+		ownSessionProvider: true,
 		opened:             false,
 	}
 	c.CachedCounters = *ccount.InheritCacheCounters(c)
@@ -96,11 +108,10 @@ func NewCloudWatchCounters() *CloudWatchCounters {
 //   - config    configuration parameters to be set.
 func (c *CloudWatchCounters) Configure(config *cconf.ConfigParams) {
 	c.CachedCounters.Configure(config)
-	c.connectionResolver.Configure(config)
+	c.sessionProvider.Configure(config)
 
 	c.source = config.GetAsStringWithDefault("source", c.source)
 	c.instance = config.GetAsStringWithDefault("instance", c.instance)
-	c.connectTimeout = config.GetAsIntegerWithDefault("options.connect_timeout", c.connectTimeout)
 }
 
 /*
@@ -110,8 +121,17 @@ func (c *CloudWatchCounters) Configure(config *cconf.ConfigParams) {
 */
 func (c *CloudWatchCounters) SetReferences(references cref.IReferences) {
 	c.logger.SetReferences(references)
-	c.connectionResolver.SetReferences(references)
+	c.sessionProvider.SetReferences(references)
+
+	// Use shared session provider when it is available
 	ref := references.GetOneOptional(
+		cref.NewDescriptor("*", "session-provider", "aws", "*", "1.0"))
+	if sessionProvider, ok := ref.(*awsconn.AwsSessionProvider); ok {
+		c.sessionProvider = sessionProvider
+		c.ownSessionProvider = false
+	}
+
+	ref = references.GetOneOptional(
 		cref.NewDescriptor("pip-services", "context-info", "default", "*", "1.0"))
 	contextInfo, ok := ref.(*cinfo.ContextInfo)
 
@@ -149,20 +169,24 @@ func (c *CloudWatchCounters) Open(correlationId string) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		connection, err := c.connectionResolver.Resolve(correlationId)
-		c.connection = connection
-		errGlobal = err
-
-		awsCred := credentials.NewStaticCredentials(c.connection.GetAccessId(), c.connection.GetAccessKey(), "")
-		sess := session.Must(session.NewSession(&aws.Config{
-			MaxRetries:  aws.Int(3),
-			Region:      aws.String(c.connection.GetRegion()),
-			Credentials: awsCred,
-		}))
-		// Create new cloudwatch client.
-		c.client = cloudwatch.New(sess)
-		c.client.APIVersion = "2010-08-01"
-		c.client.Config.HTTPClient.Timeout = time.Duration((int64)(c.connectTimeout)) * time.Millisecond
+		err := c.sessionProvider.Open(correlationId)
+		if err != nil {
+			errGlobal = err
+			return
+		}
+		c.connection = c.sessionProvider.GetConnection()
+
+		// Get cloudwatch client from the session provider.
+		client, err := c.sessionProvider.GetClient(correlationId, "cloudwatch", func(sess *session.Session) interface{} {
+			client := cloudwatch.New(sess)
+			client.APIVersion = "2010-08-01"
+			return client
+		})
+		if err != nil {
+			errGlobal = err
+			return
+		}
+		c.client = client.(*cloudwatch.CloudWatch)
 
 	}()
 	wg.Wait()
@@ -181,6 +205,9 @@ func (c *CloudWatchCounters) Open(correlationId string) error {
 func (c *CloudWatchCounters) Close(correlationId string) error {
 	c.opened = false
 	c.client = nil
+	if c.ownSessionProvider {
+		return c.sessionProvider.Close(correlationId)
+	}
 	return nil
 }
 
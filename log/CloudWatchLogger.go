@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	awsconn "github.com/pip-services3-go/pip-services3-aws-go/connect"
@@ -26,19 +25,32 @@ import (
  - connections:
      - discovery_key:               (optional) a key to retrieve the connection from IDiscovery
      - region:                      (optional) AWS region
+     - endpoint:                    (optional) custom AWS service endpoint, i.e. LocalStack
+     - s3_force_path_style:         (optional) true to use path-style addressing for S3 buckets (default: false)
+     - disable_ssl:                 (optional) true to disable SSL (default: false)
+     - ca_bundle:                   (optional) path to custom CA bundle file in PEM format
+     - sts_endpoint:                (optional) custom STS endpoint to assume the role
  - credentials:
      - store_key:                   (optional) a key to retrieve the credentials from ICredentialStore
-     - access_id:                   AWS access/client id
-     - access_key:                  AWS access/client id
+     - access_id:                   (optional) AWS access/client id
+     - access_key:                  (optional) AWS access/client key
+     - profile:                     (optional) named profile in shared AWS credentials files
+     - session_token:               (optional) session token for temporary credentials
+     - role_arn:                    (optional) ARN of the role to assume with STS
+     - external_id:                 (optional) external id to assume the role
+     - role_session_name:           (optional) session name of the assumed role
  - options:
      - interval:        interval in milliseconds to save current counters measurements (default: 5 mins)
      - reset_timeout:   timeout in milliseconds to reset the counters. 0 disables the reset (default: 0)
+     - connect_timeout: (optional) connection timeout in milliseconds (default: 30 sec)
+     - max_retries:     (optional) maximum number of retries for failed requests (default: 3)
 
  ### References ###
 
  - \*:context-info:\*:\*:1.0      (optional) ContextInfo to detect the context id and specify counters source
  - \*:discovery:\*:\*:1.0         (optional) IDiscovery services to resolve connections
  - \*:credential-store:\*:\*:1.0  (optional) Credential stores to resolve credentials
+ - \*:session-provider:aws:\*:1.0 (optional) Shared AwsSessionProvider to create AWS sessions
 
  See Counter (in the Pip.Services components package)
  See CachedCounters (in the Pip.Services components package)
@@ -73,10 +85,10 @@ type CloudWatchLogger struct {
 
 	timer chan bool
 
-	connectionResolver *awsconn.AwsConnectionResolver
+	sessionProvider    *awsconn.AwsSessionProvider
+	ownSessionProvider bool
 	client             *cloudwatchlogs.CloudWatchLogs //AmazonCloudWatchLogsClient
 	connection         *awsconn.AwsConnectionParams
-	connectTimeout     int
 
 	group     string
 	stream    string
@@ -90,8 +102,8 @@ type CloudWatchLogger struct {
 */
 func NewCloudWatchLogger() *CloudWatchLogger {
 	c := &CloudWatchLogger{
-		connectionResolver: awsconn.NewAwsConnectionResolver(),
-		connectTimeout:     30000,
+		sessionProvider:    awsconn.NewAwsSessionProvider(),
+		ownSessionProvider: true,
 		group:              "undefined",
 		stream:             "",
 		lastToken:          "",
@@ -105,11 +117,10 @@ func NewCloudWatchLogger() *CloudWatchLogger {
 //   - config    configuration parameters to be set.
 func (c *CloudWatchLogger) Configure(config *cconf.ConfigParams) {
 	c.CachedLogger.Configure(config)
-	c.connectionResolver.Configure(config)
+	c.sessionProvider.Configure(config)
 
 	c.group = config.GetAsStringWithDefault("group", c.group)
 	c.stream = config.GetAsStringWithDefault("stream", c.stream)
-	c.connectTimeout = config.GetAsIntegerWithDefault("options.connect_timeout", c.connectTimeout)
 }
 
 // SetReferences method sets references to dependent components.
@@ -118,8 +129,16 @@ func (c *CloudWatchLogger) Configure(config *cconf.ConfigParams) {
 func (c *CloudWatchLogger) SetReferences(references cref.IReferences) {
 	c.CachedLogger.SetReferences(references)
 	c.logger.SetReferences(references)
+	c.sessionProvider.SetReferences(references)
 
-	ref := references.GetOneOptional(cref.NewDescriptor("pip-services", "context-info", "default", "*", "1.0"))
+	// Use shared session provider when it is available
+	ref := references.GetOneOptional(cref.NewDescriptor("*", "session-provider", "aws", "*", "1.0"))
+	if sessionProvider, ok := ref.(*awsconn.AwsSessionProvider); ok {
+		c.sessionProvider = sessionProvider
+		c.ownSessionProvider = false
+	}
+
+	ref = references.GetOneOptional(cref.NewDescriptor("pip-services", "context-info", "default", "*", "1.0"))
 
 	contextInfo, ok := ref.(*cinfo.ContextInfo)
 	if ok && c.stream == "" {
@@ -165,23 +184,26 @@ func (c *CloudWatchLogger) Open(correlationId string) error {
 
 	go func() {
 		defer wg.Done()
-		connection, err := c.connectionResolver.Resolve(correlationId)
-		c.connection = connection
+		err := c.sessionProvider.Open(correlationId)
 		if err != nil {
 			globalErr = err
 			return
 		}
-
-		awsCred := credentials.NewStaticCredentials(c.connection.GetAccessId(), c.connection.GetAccessKey(), "")
-		sess := session.Must(session.NewSession(&aws.Config{
-			MaxRetries:  aws.Int(3),
-			Region:      aws.String(c.connection.GetRegion()),
-			Credentials: awsCred,
-		}))
-		// Create new cloudwatch client.
-		c.client = cloudwatchlogs.New(sess)
-		c.client.APIVersion = "2014-03-28"
-		c.client.Config.HTTPClient.Timeout = time.Duration((int64)(c.connectTimeout)) * time.Millisecond
+		c.connection = c.sessionProvider.GetConnection()
+
+		// Get cloudwatch logs client from the session provider.
+		// Requests of the logger are never logged to avoid a feedback loop
+		// when the provider has "options.log_requests" enabled.
+		client, err := c.sessionProvider.GetClient(correlationId, "cloudwatchlogs:logger", func(sess *session.Session) interface{} {
+			client := cloudwatchlogs.New(sess, aws.NewConfig().WithLogLevel(aws.LogOff))
+			client.APIVersion = "2014-03-28"
+			return client
+		})
+		if err != nil {
+			globalErr = err
+			return
+		}
+		c.client = client.(*cloudwatchlogs.CloudWatchLogs)
 
 		groupParam := &cloudwatchlogs.CreateLogGroupInput{
 			LogGroupName: aws.String(c.group),
@@ -252,6 +274,9 @@ func (c *CloudWatchLogger) Close(correlationId string) error {
 	c.timer = nil
 	c.client = nil
 
+	if c.ownSessionProvider {
+		c.sessionProvider.Close(correlationId)
+	}
 	return err
 }
 
@@ -100,5 +100,7 @@ func (c *CommandableLambdaService) Register() {
 			return result, err
 
 		})
+		// Commands validate their parameters, so the schema is kept only to describe the action
+		c.actions[len(c.actions)-1].ParamsSchema = CommandSchema(command)
 	}
 }
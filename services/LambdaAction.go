@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	cvalid "github.com/pip-services3-go/pip-services3-commons-go/validate"
 )
 
@@ -9,9 +11,29 @@ type LambdaAction struct {
 	//Command to call the action
 	Cmd string
 
+	//Name of the service that owns the action (optional)
+	Service string
+
 	//Schema to validate action parameters
 	Schema *cvalid.Schema
 
+	//Schema of action parameters to describe the action, like *cvalid.ObjectSchema (optional).
+	//It is used only in action descriptions, parameters are validated by the registered action.
+	ParamsSchema cvalid.ISchema
+
 	//Action to be executed
 	Action func(params map[string]interface{}) (interface{}, error)
+
+	//Action to be executed with invocation context (optional).
+	//When set, it is called instead of Action to pass deadline, cancellation and lambda context.
+	ActionWithContext func(ctx context.Context, params map[string]interface{}) (interface{}, error)
+
+	//HTTP method to route API Gateway requests to the action (optional)
+	Method string
+
+	//Route template to route API Gateway requests to the action (optional)
+	Route string
+
+	//Event sources that trigger the action (optional)
+	Events []*LambdaEventSource
 }
@@ -0,0 +1,34 @@
+package services
+
+// Type of event source for S3 event notifications
+const EventSourceS3 = "aws:s3"
+
+/*
+Defines an event source that triggers a lambda action,
+when the event does not carry "cmd" parameter.
+
+ - SNS notifications are matched by topic ARN in Source
+ - EventBridge events are matched by "source" and optional "detail-type"
+ - CloudWatch scheduled events are matched by rule name or rule ARN in Source
+ - Kinesis records are matched by stream ARN in Source
+ - DynamoDB Streams records are matched by stream or table ARN in Source and optional event name in DetailType
+ - S3 notifications are matched by bucket name or ARN in Source, event name pattern in DetailType,
+   like "ObjectCreated:*", and optional object key Prefix and Suffix
+*/
+type LambdaEventSource struct {
+
+	//Type of event source, like "aws:s3" or "aws:kinesis" (optional). When empty it is detected from Source
+	EventSource string
+
+	//SNS topic ARN, EventBridge event source, scheduled rule name, stream ARN or S3 bucket
+	Source string
+
+	//EventBridge event detail type, DynamoDB Streams or S3 event name (optional)
+	DetailType string
+
+	//S3 object key prefix (optional)
+	Prefix string
+
+	//S3 object key suffix (optional)
+	Suffix string
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+)
+
+const errorDescriptionName = "ErrorDescription"
+
+var (
+	pathParamRegex     = regexp.MustCompile(`\{([^}/]+?)\+?\}`)
+	componentNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+	methodsWithoutBody = map[string]bool{"GET": true, "DELETE": true, "HEAD": true, "OPTIONS": true}
+)
+
+/*
+Generates JSON Schemas and OpenAPI 3 document from actions registered in lambda functions or services.
+Parameter schemas are taken from the schemas actions were registered with via RegisterActionWithParamsSchema.
+CommandableLambdaService and CommandableLambdaFunction describe actions with schemas of their commands.
+Actions registered with base *cvalid.Schema are described as untyped objects.
+
+JSON Schema is generated for every action. OpenAPI document describes actions
+exposed via API Gateway routes: path parameters are taken from route templates,
+other parameters are passed in query for GET and DELETE requests and in JSON body otherwise.
+Errors are described with ErrorDescription returned by the function.
+
+### Configuration parameters ###
+
+ - name:                          (optional) API title (default: "LambdaFunction")
+ - description:                   (optional) API description (default: "AWS Lambda function")
+ - version:                       (optional) API version (default: "1")
+
+### Example ###
+
+    doc := NewLambdaOpenApiDocument(cconf.NewConfigParamsFromTuples(
+        "name", "Dummies API",
+        "version", "1.0",
+    ), function.GetActions())
+
+    schemas := doc.GetJsonSchemas()   // Result: {"create_dummy": {"type": "object", ...}, ...}
+    openApi, err := doc.ToJson()
+*/
+type LambdaOpenApiDocument struct {
+	Actions []*LambdaAction
+
+	Version string
+
+	InfoTitle       string
+	InfoDescription string
+	InfoVersion     string
+}
+
+// NewLambdaOpenApiDocument creates a new document.
+//   - config     (optional) configuration parameters with API info.
+//   - actions    actions registered in a lambda function or service, i.e. from GetActions().
+func NewLambdaOpenApiDocument(config *cconf.ConfigParams, actions []*LambdaAction) *LambdaOpenApiDocument {
+	c := &LambdaOpenApiDocument{
+		Actions: make([]*LambdaAction, 0),
+		Version: "3.0.2",
+	}
+
+	if actions != nil {
+		c.Actions = actions
+	}
+
+	if config == nil {
+		config = cconf.NewEmptyConfigParams()
+	}
+
+	c.InfoTitle = config.GetAsStringWithDefault("name", "LambdaFunction")
+	c.InfoDescription = config.GetAsStringWithDefault("description", "AWS Lambda function")
+	c.InfoVersion = config.GetAsStringWithDefault("version", "1")
+	return c
+}
+
+// Gets JSON Schemas of parameters for all actions.
+// Returns a map with JSON Schemas by action commands.
+func (c *LambdaOpenApiDocument) GetJsonSchemas() map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{})
+	for _, action := range c.Actions {
+		schema := DescribeActionSchema(action)
+		if schema == nil {
+			schema = map[string]interface{}{"type": "object"}
+		}
+		schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+		schema["title"] = action.Cmd
+		schemas[action.Cmd] = schema
+	}
+	return schemas
+}
+
+// Gets OpenAPI document as a map.
+func (c *LambdaOpenApiDocument) GetData() map[string]interface{} {
+	return map[string]interface{}{
+		"openapi": c.Version,
+		"info": map[string]interface{}{
+			"title":       c.InfoTitle,
+			"description": c.InfoDescription,
+			"version":     c.InfoVersion,
+		},
+		"paths": c.createPathsData(),
+		"components": map[string]interface{}{
+			"schemas": c.createSchemasData(),
+		},
+	}
+}
+
+// Serializes OpenAPI document into JSON string.
+func (c *LambdaOpenApiDocument) ToJson() (string, error) {
+	data, err := json.MarshalIndent(c.GetData(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *LambdaOpenApiDocument) createSchemasData() map[string]interface{} {
+	data := map[string]interface{}{
+		errorDescriptionName: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":           map[string]interface{}{"type": "string"},
+				"category":       map[string]interface{}{"type": "string"},
+				"status":         map[string]interface{}{"type": "integer"},
+				"code":           map[string]interface{}{"type": "string"},
+				"message":        map[string]interface{}{"type": "string"},
+				"details":        map[string]interface{}{"type": "object"},
+				"correlation_id": map[string]interface{}{"type": "string"},
+				"cause":          map[string]interface{}{"type": "string"},
+				"stack_trace":    map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+
+	for _, action := range c.Actions {
+		if action.Route == "" {
+			continue
+		}
+		if schema := DescribeActionSchema(action); len(schema) > 0 {
+			data[c.componentName(action.Cmd)] = schema
+		}
+	}
+	return data
+}
+
+func (c *LambdaOpenApiDocument) createPathsData() map[string]interface{} {
+	data := make(map[string]interface{})
+
+	for _, action := range c.Actions {
+		if action.Route == "" {
+			continue
+		}
+
+		path := pathParamRegex.ReplaceAllString(action.Route, "{$1}")
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+
+		operations, ok := data[path].(map[string]interface{})
+		if !ok {
+			operations = make(map[string]interface{})
+			data[path] = operations
+		}
+
+		method := strings.ToUpper(action.Method)
+		operation := c.createOperationData(action, method)
+		if method == "" || method == "*" || method == "ANY" {
+			operations["x-amazon-apigateway-any-method"] = operation
+		} else {
+			operations[strings.ToLower(method)] = operation
+		}
+	}
+
+	return data
+}
+
+func (c *LambdaOpenApiDocument) createOperationData(action *LambdaAction, method string) map[string]interface{} {
+	operation := map[string]interface{}{
+		"operationId": action.Cmd,
+		"responses":   c.createResponsesData(),
+	}
+	if action.Service != "" {
+		operation["tags"] = []string{action.Service}
+	}
+
+	schema := DescribeActionSchema(action)
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	if names, ok := schema["required"].([]string); ok {
+		for _, name := range names {
+			required[name] = true
+		}
+	}
+
+	parameters := make([]interface{}, 0)
+	pathParams := make(map[string]bool)
+	for _, match := range pathParamRegex.FindAllStringSubmatch(action.Route, -1) {
+		name := match[1]
+		pathParams[name] = true
+		parameters = append(parameters, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   c.createParameterSchema(properties[name]),
+		})
+	}
+
+	if methodsWithoutBody[method] {
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			if !pathParams[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     name,
+				"in":       "query",
+				"required": required[name],
+				"schema":   c.createParameterSchema(properties[name]),
+			})
+		}
+	} else if len(schema) > 0 {
+		operation["requestBody"] = map[string]interface{}{
+			"required": len(required) > 0,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{
+						"$ref": "#/components/schemas/" + c.componentName(action.Cmd),
+					},
+				},
+			},
+		}
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	return operation
+}
+
+func (c *LambdaOpenApiDocument) createParameterSchema(property interface{}) interface{} {
+	if schema, ok := property.(map[string]interface{}); ok && len(schema) > 0 {
+		return schema
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+func (c *LambdaOpenApiDocument) createResponsesData() map[string]interface{} {
+	return map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "Successful response",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{
+						"type": "object",
+					},
+				},
+			},
+		},
+		"default": map[string]interface{}{
+			"description": "Error response",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{
+						"$ref": "#/components/schemas/" + errorDescriptionName,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Converts command into a valid name of OpenAPI component.
+func (c *LambdaOpenApiDocument) componentName(cmd string) string {
+	return componentNameRegex.ReplaceAllString(cmd, "_")
+}
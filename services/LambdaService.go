@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
 	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
 	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
@@ -25,6 +27,14 @@ exploses registered actions externally.
 
 - dependencies:
   - controller:            override for Controller dependency
+- events:
+  - <name>:                event subscription that triggers an action
+    - cmd:                 (optional) action name (default: subscription name)
+    - event_source:        (optional) type of event source, like "aws:s3" (default: detected from source)
+    - source:              SNS topic ARN, EventBridge event source, scheduled rule name, stream ARN or S3 bucket
+    - detail_type:         (optional) EventBridge event detail type, DynamoDB Streams or S3 event name
+    - prefix:              (optional) S3 object key prefix
+    - suffix:              (optional) S3 object key suffix
 
 ### References ###
 
@@ -82,6 +92,7 @@ type LambdaService struct { // ILambdaService, IOpenable, IConfigurable, IRefere
 
 	name         string
 	actions      []*LambdaAction
+	events       map[string][]*LambdaEventSource
 	interceptors []func(params map[string]interface{}, next func(params map[string]interface{}) (interface{}, error)) (interface{}, error)
 	opened       bool
 
@@ -104,6 +115,7 @@ func InheritLambdaService(overrides ILambdaServiceOverrides, name string) *Lambd
 		Overrides:          overrides,
 		name:               name,
 		actions:            make([]*LambdaAction, 0),
+		events:             make(map[string][]*LambdaEventSource),
 		interceptors:       make([]func(params map[string]interface{}, next func(params map[string]interface{}) (interface{}, error)) (interface{}, error), 0),
 		DependencyResolver: cref.NewDependencyResolver(),
 		Logger:             clog.NewCompositeLogger(),
@@ -116,6 +128,20 @@ func InheritLambdaService(overrides ILambdaServiceOverrides, name string) *Lambd
 // -  config    configuration parameters to be set.
 func (c *LambdaService) Configure(config *cconf.ConfigParams) {
 	c.DependencyResolver.Configure(config)
+
+	events := config.GetSection("events")
+	for _, name := range events.GetSectionNames() {
+		event := events.GetSection(name)
+		cmd := event.GetAsStringWithDefault("cmd", name)
+		source := &LambdaEventSource{
+			EventSource: event.GetAsString("event_source"),
+			Source:      event.GetAsString("source"),
+			DetailType:  event.GetAsString("detail_type"),
+			Prefix:      event.GetAsString("prefix"),
+			Suffix:      event.GetAsString("suffix"),
+		}
+		c.events[cmd] = append(c.events[cmd], source)
+	}
 }
 
 // Sets references to dependent components.
@@ -184,6 +210,14 @@ func (c *LambdaService) Close(correlationId string) error {
 }
 
 func (c *LambdaService) ApplyValidation(schema *cvalid.Schema, action func(params map[string]interface{}) (interface{}, error)) func(map[string]interface{}) (interface{}, error) {
+	if schema == nil {
+		return c.applyParamsValidation(nil, action)
+	}
+	return c.applyParamsValidation(schema, action)
+}
+
+// Same as ApplyValidation for any validation schema, like *cvalid.ObjectSchema.
+func (c *LambdaService) applyParamsValidation(schema cvalid.ISchema, action func(params map[string]interface{}) (interface{}, error)) func(map[string]interface{}) (interface{}, error) {
 	// Create an action function
 	actionWrapper := func(params map[string]interface{}) (interface{}, error) {
 		// Validate object
@@ -216,6 +250,42 @@ func (c *LambdaService) ApplyInterceptors(action func(map[string]interface{}) (i
 	return actionWrapper
 }
 
+// Same as ApplyValidation for actions that receive invocation context.
+func (c *LambdaService) applyValidationWithContext(schema *cvalid.Schema,
+	action func(ctx context.Context, params map[string]interface{}) (interface{}, error)) func(context.Context, map[string]interface{}) (interface{}, error) {
+
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		if schema != nil && params != nil {
+			correlationId, _ := params["correlation_id"].(string)
+			err := schema.ValidateAndReturnError(correlationId, params, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return action(ctx, params)
+	}
+}
+
+// Same as ApplyInterceptors for actions that receive invocation context.
+// The chain is built once, the context is passed to the next action on every call.
+func (c *LambdaService) applyInterceptorsWithContext(
+	action func(context.Context, map[string]interface{}) (interface{}, error)) func(context.Context, map[string]interface{}) (interface{}, error) {
+
+	actionWrapper := action
+
+	for index := len(c.interceptors) - 1; index >= 0; index-- {
+		interceptor := c.interceptors[index]
+		next := actionWrapper
+		actionWrapper = func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return interceptor(params, func(params map[string]interface{}) (interface{}, error) {
+				return next(ctx, params)
+			})
+		}
+	}
+
+	return actionWrapper
+}
+
 func (c *LambdaService) GenerateActionCmd(name string) string {
 	cmd := name
 	if c.name != "" {
@@ -233,13 +303,97 @@ func (c *LambdaService) RegisterAction(name string, schema *cvalid.Schema, actio
 	actionWrapper = c.ApplyInterceptors(actionWrapper)
 
 	registeredAction := &LambdaAction{
-		Cmd:    c.GenerateActionCmd(name),
-		Schema: schema,
-		Action: func(params map[string]interface{}) (interface{}, error) { return actionWrapper(params) },
+		Cmd:     c.GenerateActionCmd(name),
+		Service: c.name,
+		Schema:  schema,
+		Action:  func(params map[string]interface{}) (interface{}, error) { return actionWrapper(params) },
+		Events:  c.events[name],
 	}
 	c.actions = append(c.actions, registeredAction)
 }
 
+// Registers a action in AWS Lambda function with a schema that also describes
+// action parameters in "_actions" command and generated OpenAPI documents.
+// -  name          an action name
+// -  schema        a schema to validate and describe received parameters, like *cvalid.ObjectSchema.
+// -  action        an action function that is called when operation is invoked.
+func (c *LambdaService) RegisterActionWithParamsSchema(name string, schema cvalid.ISchema, action func(params map[string]interface{}) (interface{}, error)) {
+	actionWrapper := c.applyParamsValidation(schema, action)
+	actionWrapper = c.ApplyInterceptors(actionWrapper)
+
+	registeredAction := &LambdaAction{
+		Cmd:          c.GenerateActionCmd(name),
+		Service:      c.name,
+		ParamsSchema: schema,
+		Action:       func(params map[string]interface{}) (interface{}, error) { return actionWrapper(params) },
+		Events:       c.events[name],
+	}
+	c.actions = append(c.actions, registeredAction)
+}
+
+// Registers an action in AWS Lambda function that receives invocation context.
+// The context carries invocation deadline, cancellation and lambda context
+// with AWS request id, that can be retrieved by lambdacontext.FromContext.
+// -  name          an action name
+// -  schema        a validation schema to validate received parameters.
+// -  action        an action function that is called when operation is invoked.
+func (c *LambdaService) RegisterActionWithContext(name string, schema *cvalid.Schema,
+	action func(ctx context.Context, params map[string]interface{}) (interface{}, error)) {
+
+	actionWithContext := c.applyValidationWithContext(schema, action)
+	actionWithContext = c.applyInterceptorsWithContext(actionWithContext)
+
+	registeredAction := &LambdaAction{
+		Cmd:     c.GenerateActionCmd(name),
+		Service: c.name,
+		Schema:  schema,
+		Action: func(params map[string]interface{}) (interface{}, error) {
+			return actionWithContext(context.Background(), params)
+		},
+		ActionWithContext: actionWithContext,
+		Events:            c.events[name],
+	}
+	c.actions = append(c.actions, registeredAction)
+}
+
+// Registers an action in AWS Lambda function and exposes it
+// via API Gateway under the specified HTTP method and route.
+// -  name          an action name
+// -  method        an HTTP method
+// -  route         a route template, like "/dummies/{dummy_id}"
+// -  schema        a validation schema to validate received parameters.
+// -  action        an action function that is called when operation is invoked.
+func (c *LambdaService) RegisterActionWithRoute(name string, method string, route string, schema *cvalid.Schema,
+	action func(params map[string]interface{}) (interface{}, error)) {
+	c.RegisterAction(name, schema, action)
+
+	registeredAction := c.actions[len(c.actions)-1]
+	registeredAction.Method = method
+	registeredAction.Route = route
+}
+
+// Registers an action in AWS Lambda function triggered by S3 event notifications.
+// -  name          an action name
+// -  bucket        an S3 bucket name or ARN
+// -  eventName     an S3 event name or pattern, like "ObjectCreated:*"
+// -  prefix        (optional) an object key prefix
+// -  suffix        (optional) an object key suffix
+// -  schema        a validation schema to validate received parameters.
+// -  action        an action function that is called when operation is invoked.
+func (c *LambdaService) RegisterActionWithS3Events(name string, bucket string, eventName string, prefix string, suffix string,
+	schema *cvalid.Schema, action func(params map[string]interface{}) (interface{}, error)) {
+	c.RegisterAction(name, schema, action)
+
+	registeredAction := c.actions[len(c.actions)-1]
+	registeredAction.Events = append(registeredAction.Events, &LambdaEventSource{
+		EventSource: EventSourceS3,
+		Source:      bucket,
+		DetailType:  eventName,
+		Prefix:      prefix,
+		Suffix:      suffix,
+	})
+}
+
 // Registers an action with authorization.
 // -  name          an action name
 // -  schema        a validation schema to validate received parameters.
@@ -257,9 +411,11 @@ func (c *LambdaService) RegisterActionWithAuth(name string, schema *cvalid.Schem
 	actionWrapper = c.ApplyInterceptors(actionWrapper)
 
 	registeredAction := &LambdaAction{
-		Cmd:    c.GenerateActionCmd(name),
-		Schema: schema,
-		Action: func(params map[string]interface{}) (interface{}, error) { return actionWrapper(params) },
+		Cmd:     c.GenerateActionCmd(name),
+		Service: c.name,
+		Schema:  schema,
+		Action:  func(params map[string]interface{}) (interface{}, error) { return actionWrapper(params) },
+		Events:  c.events[name],
 	}
 	c.actions = append(c.actions, registeredAction)
 }
@@ -311,5 +467,8 @@ func (c *LambdaService) Act(params map[string]interface{}) (interface{}, error)
 			WithDetails("command", cmd)
 	}
 
+	if action.ActionWithContext != nil {
+		return action.ActionWithContext(context.TODO(), params)
+	}
 	return action.Action(params)
 }
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"strings"
+
+	ccomands "github.com/pip-services3-go/pip-services3-commons-go/commands"
+	cconv "github.com/pip-services3-go/pip-services3-commons-go/convert"
+	cvalid "github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+DescribeSchema converts validation schema into JSON Schema description.
+Object, array and map schemas are converted with their properties and value types,
+type codes are converted into JSON types. Custom schemas that embed ObjectSchema are
+described with their properties. Other schemas, including base *cvalid.Schema
+that actions are registered with, are described as objects. Use RegisterActionWithParamsSchema
+to register actions with describable schemas.
+
+   - schema    a validation schema, like *cvalid.ObjectSchema, or a type code.
+Returns JSON Schema description or nil when the schema is not set.
+
+### Example ###
+
+    schema := cvalid.NewObjectSchema().
+        WithRequiredProperty("dummy_id", cconv.String)
+
+    description := DescribeSchema(schema)
+    // Result: {"type": "object", "properties": {"dummy_id": {"type": "string"}}, "required": ["dummy_id"]}
+*/
+func DescribeSchema(schema interface{}) map[string]interface{} {
+	switch value := schema.(type) {
+	case nil:
+		return nil
+	case *cvalid.ObjectSchema:
+		if value == nil {
+			return nil
+		}
+		return describeProperties(value.Properties())
+	case *cvalid.ArraySchema:
+		if value == nil {
+			return nil
+		}
+		result := map[string]interface{}{"type": "array"}
+		if items := describeType(value.ValueType()); len(items) > 0 {
+			result["items"] = items
+		}
+		return result
+	case *cvalid.MapSchema:
+		if value == nil {
+			return nil
+		}
+		result := map[string]interface{}{"type": "object"}
+		if values := describeType(value.ValueType()); len(values) > 0 {
+			result["additionalProperties"] = values
+		}
+		return result
+	case *cvalid.Schema:
+		if value == nil {
+			return nil
+		}
+		return map[string]interface{}{"type": "object"}
+	case interface{ Properties() []*cvalid.PropertySchema }:
+		// Custom schemas that embed ObjectSchema, like DummySchema
+		return describeProperties(value.Properties())
+	case cvalid.ISchema:
+		return map[string]interface{}{"type": "object"}
+	}
+
+	return describeType(schema)
+}
+
+/*
+DescribeActionSchema converts parameters schema of the action into JSON Schema description.
+ParamsSchema is used when it is set, otherwise the validation schema is described.
+   - action    a registered action.
+Returns JSON Schema description or nil when the action has no schema.
+*/
+func DescribeActionSchema(action *LambdaAction) map[string]interface{} {
+	if action.ParamsSchema != nil {
+		return DescribeSchema(action.ParamsSchema)
+	}
+	return DescribeSchema(action.Schema)
+}
+
+func describeProperties(schemas []*cvalid.PropertySchema) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	for _, property := range schemas {
+		properties[property.Name()] = describeType(property.Type())
+		if property.Required() {
+			required = append(required, property.Name())
+		}
+	}
+	result := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	return result
+}
+
+func describeType(typ interface{}) map[string]interface{} {
+	if typ == nil {
+		return map[string]interface{}{}
+	}
+
+	switch value := typ.(type) {
+	case cconv.TypeCode:
+		return describeTypeCode(value)
+	case string:
+		return describeTypeName(value)
+	case cvalid.ISchema:
+		return DescribeSchema(value)
+	}
+	return map[string]interface{}{}
+}
+
+func describeTypeCode(typ cconv.TypeCode) map[string]interface{} {
+	switch typ {
+	case cconv.String, cconv.Enum:
+		return map[string]interface{}{"type": "string"}
+	case cconv.Boolean:
+		return map[string]interface{}{"type": "boolean"}
+	case cconv.Integer, cconv.Long, cconv.Duration:
+		return map[string]interface{}{"type": "integer"}
+	case cconv.Float, cconv.Double:
+		return map[string]interface{}{"type": "number"}
+	case cconv.DateTime:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case cconv.Object, cconv.Map:
+		return map[string]interface{}{"type": "object"}
+	case cconv.Array:
+		return map[string]interface{}{"type": "array"}
+	}
+	return map[string]interface{}{}
+}
+
+func describeTypeName(typ string) map[string]interface{} {
+	switch strings.ToLower(typ) {
+	case "string":
+		return describeTypeCode(cconv.String)
+	case "bool", "boolean":
+		return describeTypeCode(cconv.Boolean)
+	case "int", "int32", "int64", "integer", "long":
+		return describeTypeCode(cconv.Integer)
+	case "float", "float32", "float64", "double", "number":
+		return describeTypeCode(cconv.Double)
+	case "datetime", "time":
+		return describeTypeCode(cconv.DateTime)
+	case "object", "map":
+		return describeTypeCode(cconv.Object)
+	case "array":
+		return describeTypeCode(cconv.Array)
+	}
+	return map[string]interface{}{}
+}
+
+/*
+CommandSchema gets parameters schema of a command to describe the action that executes it.
+   - command    a command from controller's CommandSet.
+Returns the command schema or nil when the command has no schema.
+*/
+func CommandSchema(command ccomands.ICommand) cvalid.ISchema {
+	cmd, ok := command.(interface{ GetSchema() cvalid.ISchema })
+	if !ok {
+		return nil
+	}
+	return cmd.GetSchema()
+}
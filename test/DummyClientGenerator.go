@@ -0,0 +1,27 @@
+package test
+
+import (
+	"reflect"
+
+	awsgen "github.com/pip-services3-go/pip-services3-aws-go/clients/generator"
+	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
+)
+
+// NewDummyClientGenerator creates a generator of typed client for DummyCommandSet commands.
+func NewDummyClientGenerator() *awsgen.CommandableLambdaClientGenerator {
+	generator := awsgen.NewCommandableLambdaClientGenerator("test", "DummyGeneratedLambdaClient", "dummy")
+	generator.Imports["github.com/pip-services3-go/pip-services3-aws-go/test"] = "awstest"
+
+	dummyType := reflect.TypeOf(&Dummy{})
+	generator.SetResultType("get_dummies", reflect.TypeOf(&DummyDataPage{}))
+	generator.SetResultType("get_dummy_by_id", dummyType)
+	generator.SetResultType("create_dummy", dummyType)
+	generator.SetResultType("update_dummy", dummyType)
+	generator.SetResultType("delete_dummy", dummyType)
+
+	generator.SetParamType("get_dummies", "filter", reflect.TypeOf(&cdata.FilterParams{}))
+	generator.SetParamType("get_dummies", "paging", reflect.TypeOf(&cdata.PagingParams{}))
+	generator.SetParamType("create_dummy", "dummy", dummyType.Elem())
+	generator.SetParamType("update_dummy", "dummy", dummyType.Elem())
+	return generator
+}
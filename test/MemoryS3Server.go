@@ -0,0 +1,69 @@
+package test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// MemoryS3Server is a local S3 stand-in that keeps objects in memory.
+// It supports path-style PUT, GET and DELETE object requests.
+type MemoryS3Server struct {
+	*httptest.Server
+
+	lock    sync.Mutex
+	objects map[string][]byte
+	puts    int
+}
+
+func NewMemoryS3Server() *MemoryS3Server {
+	c := &MemoryS3Server{
+		objects: make(map[string][]byte),
+	}
+	c.Server = httptest.NewServer(http.HandlerFunc(c.handleRequest))
+	return c
+}
+
+func (c *MemoryS3Server) ObjectCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.objects)
+}
+
+func (c *MemoryS3Server) PutCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.puts
+}
+
+func (c *MemoryS3Server) handleRequest(w http.ResponseWriter, r *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	path := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		c.objects[path] = body
+		c.puts++
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		body, ok := c.objects[path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	case http.MethodDelete:
+		delete(c.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := awsclient.NewCircuitBreaker()
+	breaker.Configure(cconf.NewConfigParamsFromTuples(
+		"options.circuit_breaker.enabled", true,
+		"options.circuit_breaker.failure_threshold", 2,
+		"options.circuit_breaker.open_timeout", 50,
+	))
+
+	changes := make([]string, 0)
+	breaker.OnStateChange = func(correlationId string, from string, to string) {
+		changes = append(changes, from+"->"+to)
+	}
+
+	failure := cerr.NewConnectionError("123", "CONNECTION_FAILED", "Connection failed")
+
+	// Business errors do not open the circuit
+	probe, err := breaker.Allow("123")
+	assert.Nil(t, err)
+	assert.False(t, probe)
+	breaker.Record("123", probe, cerr.NewNotFoundError("123", "NOT_FOUND", "Not found"))
+	probe, err = breaker.Allow("123")
+	assert.Nil(t, err)
+	breaker.Record("123", probe, failure)
+	assert.Equal(t, awsclient.CircuitClosed, breaker.GetState())
+
+	// Call admitted in closed state completes after the circuit opened
+	stale, err := breaker.Allow("123")
+	assert.Nil(t, err)
+
+	probe, err = breaker.Allow("123")
+	assert.Nil(t, err)
+	breaker.Record("123", probe, failure)
+	assert.Equal(t, awsclient.CircuitOpen, breaker.GetState())
+
+	_, err = breaker.Allow("123")
+	assert.NotNil(t, err)
+	assert.Equal(t, "CIRCUIT_OPEN", err.(*cerr.ApplicationError).Code)
+
+	// Only one probe is allowed in half-open state
+	time.Sleep(60 * time.Millisecond)
+	probe, err = breaker.Allow("123")
+	assert.Nil(t, err)
+	assert.True(t, probe)
+	assert.Equal(t, awsclient.CircuitHalfOpen, breaker.GetState())
+	_, err = breaker.Allow("123")
+	assert.NotNil(t, err)
+
+	// Results of non-probe calls do not free probes or change the state
+	breaker.Record("123", stale, nil)
+	assert.Equal(t, awsclient.CircuitHalfOpen, breaker.GetState())
+	_, err = breaker.Allow("123")
+	assert.NotNil(t, err)
+
+	// Released probe is not counted
+	breaker.Release("123", probe)
+	assert.Equal(t, awsclient.CircuitHalfOpen, breaker.GetState())
+	probe, err = breaker.Allow("123")
+	assert.Nil(t, err)
+	assert.True(t, probe)
+
+	// Failed probe opens the circuit again
+	breaker.Record("123", probe, failure)
+	assert.Equal(t, awsclient.CircuitOpen, breaker.GetState())
+
+	time.Sleep(60 * time.Millisecond)
+	probe, err = breaker.Allow("123")
+	assert.Nil(t, err)
+	breaker.Record("123", probe, nil)
+	assert.Equal(t, awsclient.CircuitClosed, breaker.GetState())
+
+	assert.Equal(t, []string{
+		"closed->open", "open->half_open", "half_open->open",
+		"open->half_open", "half_open->closed",
+	}, changes)
+}
+
+func TestLambdaClientCircuitBreaker(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(10, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server,
+		"options.circuit_breaker.enabled", true,
+		"options.circuit_breaker.failure_threshold", 2,
+	)
+	defer client.Close("")
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Call(nil, "get_dummies", "123", map[string]interface{}{})
+		assert.Equal(t, "TOO_MANY_REQUESTS", err.(*cerr.ApplicationError).Code)
+	}
+
+	// Open circuit fails fast without calling the function
+	_, err := client.Call(nil, "get_dummies", "123", map[string]interface{}{})
+	assert.Equal(t, "CIRCUIT_OPEN", err.(*cerr.ApplicationError).Code)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestLambdaClientCircuitBreakerIgnoresCallerDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := newRetryingClient(t, server,
+		"options.circuit_breaker.enabled", true,
+		"options.circuit_breaker.failure_threshold", 1,
+	)
+	defer client.Close("")
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := client.CallWithContext(ctx, nil, "get_dummies", "123", map[string]interface{}{})
+		cancel()
+		assert.NotNil(t, err)
+		assert.NotEqual(t, "CIRCUIT_OPEN", err.(*cerr.ApplicationError).Code)
+	}
+	assert.Equal(t, awsclient.CircuitClosed, client.CircuitBreaker.GetState())
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	awsgen "github.com/pip-services3-go/pip-services3-aws-go/clients/generator"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	testcont "github.com/pip-services3-go/pip-services3-aws-go/test/container"
+	ccomands "github.com/pip-services3-go/pip-services3-commons-go/commands"
+	cconv "github.com/pip-services3-go/pip-services3-commons-go/convert"
+	crun "github.com/pip-services3-go/pip-services3-commons-go/run"
+	cvalid "github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyGeneratedLambdaClientIsInSync(t *testing.T) {
+	commandSet := awstest.NewDummyCommandSet(nil)
+	source, err := awstest.NewDummyClientGenerator().Generate(&commandSet.CommandSet)
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadFile("DummyGeneratedLambdaClient.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(content), string(source), "Run go generate to update DummyGeneratedLambdaClient")
+}
+
+func TestDummyGeneratedLambdaClientWithEmulator(t *testing.T) {
+	function := testcont.NewDummyCommandableLambdaFunction()
+	emulator := openLambdaEmulator(t, function.LambdaFunction)
+	defer function.Close("")
+	defer emulator.Close("")
+
+	client := NewDummyGeneratedLambdaClient()
+	client.Configure(newEmulatorClientConfig(emulator))
+	err := client.Open("")
+	assert.Nil(t, err)
+	defer client.Close("")
+
+	var _ IDummyGeneratedLambdaClient = client
+	fixture := awstest.NewDummyClientFixture(client)
+	t.Run("DummyGeneratedLambdaClient.CrudOperations", fixture.TestCrudOperations)
+}
+
+func TestCommandableLambdaClientGeneratorDefaults(t *testing.T) {
+	action := func(correlationId string, args *crun.Parameters) (interface{}, error) { return nil, nil }
+	commandSet := ccomands.NewCommandSet()
+	commandSet.AddCommand(ccomands.NewCommand("get_stats",
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("type", cconv.String).
+			WithOptionalProperty("from_time", cconv.DateTime).
+			WithOptionalProperty("keys", cvalid.NewArraySchema(cconv.String)),
+		action))
+	commandSet.AddCommand(ccomands.NewCommand("reset-stats", nil, action))
+
+	generator := awsgen.NewCommandableLambdaClientGenerator("stats", "StatsLambdaClient", "stats")
+	source, err := generator.Generate(commandSet)
+	assert.Nil(t, err)
+
+	code := string(source)
+	assert.Contains(t, code, "type IStatsLambdaClient interface")
+	assert.Contains(t, code, "GetStats(correlationId string, typeParam string, fromTime time.Time, keys []string) (result interface{}, err error)")
+	assert.Contains(t, code, `params.SetAsObject("type", typeParam)`)
+	assert.Contains(t, code, "ResetStats(correlationId string) (result interface{}, err error)")
+	assert.Contains(t, code, "\"time\"")
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/lambda"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertErrors(t *testing.T) {
+	// Restore error from envelope
+	err := awsclient.ConvertErrorEnvelope([]byte(`{"error":{"category":"NotFound","status":404,"code":"DUMMY_NOT_FOUND","message":"Dummy was not found","details":{"id":"1"},"correlation_id":"123"}}`))
+	assert.NotNil(t, err)
+	assert.Equal(t, cerr.NotFound, err.Category)
+	assert.Equal(t, "DUMMY_NOT_FOUND", err.Code)
+	assert.Equal(t, 404, err.Status)
+	assert.Equal(t, "123", err.CorrelationId)
+	assert.Equal(t, "1", err.Details["id"])
+
+	// Skip regular results
+	assert.Nil(t, awsclient.ConvertErrorEnvelope([]byte(`{"id":"1","key":"Key 1"}`)))
+	assert.Nil(t, awsclient.ConvertErrorEnvelope([]byte(`null`)))
+
+	// Restore error from function error payload
+	err = awsclient.ConvertFunctionError("123", "Handled", []byte(`{"errorMessage":"{\"category\":\"Conflict\",\"status\":409,\"code\":\"DUPLICATE\",\"message\":\"Duplicate\"}","errorType":"ApplicationError"}`))
+	assert.Equal(t, cerr.Conflict, err.Category)
+	assert.Equal(t, "DUPLICATE", err.Code)
+
+	err = awsclient.ConvertFunctionError("123", "Handled", []byte(`{"errorMessage":"Something failed","errorType":"errorString"}`))
+	assert.Equal(t, cerr.FailedInvocation, err.Category)
+	assert.Equal(t, "Something failed", err.Message)
+	assert.Equal(t, "123", err.CorrelationId)
+	assert.Equal(t, "errorString", err.Details["error_type"])
+	assert.Equal(t, "Handled", err.Details["function_error"])
+
+	// Detect function timeouts
+	err = awsclient.ConvertFunctionError("123", "Unhandled", []byte(`{"errorMessage":"2021-01-01T00:00:00Z 1 Task timed out after 3.00 seconds"}`))
+	assert.Equal(t, cerr.FailedInvocation, err.Category)
+	assert.Equal(t, "FUNCTION_TIMEOUT", err.Code)
+	assert.Equal(t, 504, err.Status)
+
+	err = awsclient.ConvertFunctionError("123", "Unhandled", []byte(`{"errorMessage":"Runtime exited","errorType":"Runtime.ExitError"}`))
+	assert.Equal(t, "UNHANDLED_ERROR", err.Code)
+}
+
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func TestConvertInvokeErrors(t *testing.T) {
+	cause := awserr.New(lambda.ErrCodeTooManyRequestsException, "Rate exceeded", nil)
+	err := awsclient.ConvertInvokeError("123", cause)
+	assert.Equal(t, "TOO_MANY_REQUESTS", err.Code)
+	assert.Equal(t, 429, err.Status)
+	assert.Equal(t, "123", err.CorrelationId)
+	assert.NotEmpty(t, err.Cause)
+
+	err = awsclient.ConvertInvokeError("123", awserr.New(lambda.ErrCodeRequestTooLargeException, "Too large", nil))
+	assert.Equal(t, cerr.BadRequest, err.Category)
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", err.Code)
+	assert.Equal(t, 413, err.Status)
+
+	err = awsclient.ConvertInvokeError("123", awserr.New(lambda.ErrCodeResourceNotFoundException, "Not found", nil))
+	assert.Equal(t, cerr.NotFound, err.Category)
+
+	err = awsclient.ConvertInvokeError("123", awserr.New(request.ErrCodeRequestError, "send request failed", &timeoutError{}))
+	assert.Equal(t, cerr.NoResponse, err.Category)
+	assert.Equal(t, "CALL_TIMEOUT", err.Code)
+
+	err = awsclient.ConvertInvokeError("123", errors.New("Something failed"))
+	assert.Equal(t, cerr.FailedInvocation, err.Category)
+	assert.Equal(t, "CALL_FAILED", err.Code)
+	assert.Equal(t, "Something failed", err.Cause)
+}
@@ -6,6 +6,8 @@ import (
 	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
 )
 
+//go:generate go run ./generate
+
 type DummyCommandableLambdaClient struct {
 	*awsclient.CommandableLambdaClient
 }
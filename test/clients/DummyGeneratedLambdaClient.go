@@ -0,0 +1,101 @@
+// Code generated by CommandableLambdaClientGenerator. DO NOT EDIT.
+
+package test
+
+import (
+	"reflect"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cdata "github.com/pip-services3-go/pip-services3-commons-go/data"
+)
+
+type IDummyGeneratedLambdaClient interface {
+	GetDummies(correlationId string, filter *cdata.FilterParams, paging *cdata.PagingParams) (result *awstest.DummyDataPage, err error)
+	GetDummyById(correlationId string, dummyId string) (result *awstest.Dummy, err error)
+	CreateDummy(correlationId string, dummy awstest.Dummy) (result *awstest.Dummy, err error)
+	UpdateDummy(correlationId string, dummy awstest.Dummy) (result *awstest.Dummy, err error)
+	DeleteDummy(correlationId string, dummyId string) (result *awstest.Dummy, err error)
+}
+
+type DummyGeneratedLambdaClient struct {
+	*awsclient.CommandableLambdaClient
+}
+
+func NewDummyGeneratedLambdaClient() *DummyGeneratedLambdaClient {
+	c := &DummyGeneratedLambdaClient{
+		CommandableLambdaClient: awsclient.NewCommandableLambdaClient("dummy"),
+	}
+	return c
+}
+
+func (c *DummyGeneratedLambdaClient) GetDummies(correlationId string, filter *cdata.FilterParams, paging *cdata.PagingParams) (result *awstest.DummyDataPage, err error) {
+
+	params := cdata.NewEmptyAnyValueMap()
+	params.SetAsObject("filter", filter)
+	params.SetAsObject("paging", paging)
+
+	calValue, calErr := c.CallCommand(reflect.TypeOf((*awstest.DummyDataPage)(nil)), "get_dummies", correlationId, params)
+	if calErr != nil {
+		return nil, calErr
+	}
+
+	result, _ = calValue.(*awstest.DummyDataPage)
+	return result, nil
+}
+
+func (c *DummyGeneratedLambdaClient) GetDummyById(correlationId string, dummyId string) (result *awstest.Dummy, err error) {
+
+	params := cdata.NewEmptyAnyValueMap()
+	params.SetAsObject("dummy_id", dummyId)
+
+	calValue, calErr := c.CallCommand(reflect.TypeOf((*awstest.Dummy)(nil)), "get_dummy_by_id", correlationId, params)
+	if calErr != nil {
+		return nil, calErr
+	}
+
+	result, _ = calValue.(*awstest.Dummy)
+	return result, nil
+}
+
+func (c *DummyGeneratedLambdaClient) CreateDummy(correlationId string, dummy awstest.Dummy) (result *awstest.Dummy, err error) {
+
+	params := cdata.NewEmptyAnyValueMap()
+	params.SetAsObject("dummy", dummy)
+
+	calValue, calErr := c.CallCommand(reflect.TypeOf((*awstest.Dummy)(nil)), "create_dummy", correlationId, params)
+	if calErr != nil {
+		return nil, calErr
+	}
+
+	result, _ = calValue.(*awstest.Dummy)
+	return result, nil
+}
+
+func (c *DummyGeneratedLambdaClient) UpdateDummy(correlationId string, dummy awstest.Dummy) (result *awstest.Dummy, err error) {
+
+	params := cdata.NewEmptyAnyValueMap()
+	params.SetAsObject("dummy", dummy)
+
+	calValue, calErr := c.CallCommand(reflect.TypeOf((*awstest.Dummy)(nil)), "update_dummy", correlationId, params)
+	if calErr != nil {
+		return nil, calErr
+	}
+
+	result, _ = calValue.(*awstest.Dummy)
+	return result, nil
+}
+
+func (c *DummyGeneratedLambdaClient) DeleteDummy(correlationId string, dummyId string) (result *awstest.Dummy, err error) {
+
+	params := cdata.NewEmptyAnyValueMap()
+	params.SetAsObject("dummy_id", dummyId)
+
+	calValue, calErr := c.CallCommand(reflect.TypeOf((*awstest.Dummy)(nil)), "delete_dummy", correlationId, params)
+	if calErr != nil {
+		return nil, calErr
+	}
+
+	result, _ = calValue.(*awstest.Dummy)
+	return result, nil
+}
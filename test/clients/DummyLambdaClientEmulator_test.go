@@ -0,0 +1,131 @@
+package test
+
+import (
+	"testing"
+
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	awscont "github.com/pip-services3-go/pip-services3-aws-go/container"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	testcont "github.com/pip-services3-go/pip-services3-aws-go/test/container"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func openLambdaEmulator(t *testing.T, function *awscont.LambdaFunction) *awscont.LambdaEmulator {
+	function.Configure(cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+	))
+	function.SetReferences(cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), awstest.NewDummyController(),
+	))
+	err := function.Open("")
+	assert.Nil(t, err)
+
+	emulator := awscont.NewLambdaEmulator(function)
+	emulator.Configure(cconf.NewConfigParamsFromTuples(
+		"connection.port", 0,
+	))
+	err = emulator.Open("")
+	assert.Nil(t, err)
+	return emulator
+}
+
+func newEmulatorClientConfig(emulator *awscont.LambdaEmulator) *cconf.ConfigParams {
+	return cconf.NewConfigParamsFromTuples(
+		"connection.arn", "arn:aws:lambda:us-east-1:000000000000:function:dummy",
+		"connection.endpoint", emulator.GetEndpoint(),
+		"credential.access_id", "test",
+		"credential.access_key", "test",
+	)
+}
+
+func TestDummyLambdaClientWithEmulator(t *testing.T) {
+	function := testcont.NewDummyLambdaFunction()
+	emulator := openLambdaEmulator(t, function.LambdaFunction)
+	defer function.Close("")
+	defer emulator.Close("")
+
+	client := NewDummyLambdaClient()
+	client.Configure(newEmulatorClientConfig(emulator))
+	err := client.Open("")
+	assert.Nil(t, err)
+	defer client.Close("")
+
+	fixture := awstest.NewDummyClientFixture(client)
+	t.Run("DummyLambdaClient.CrudOperations", fixture.TestCrudOperations)
+}
+
+func TestDummyCommandableLambdaClientWithEmulator(t *testing.T) {
+	function := testcont.NewDummyCommandableLambdaFunction()
+	emulator := openLambdaEmulator(t, function.LambdaFunction)
+	defer function.Close("")
+	defer emulator.Close("")
+
+	client := NewDummyCommandableLambdaClient()
+	client.Configure(newEmulatorClientConfig(emulator))
+	err := client.Open("")
+	assert.Nil(t, err)
+	defer client.Close("")
+
+	fixture := awstest.NewDummyClientFixture(client)
+	t.Run("DummyCommandableLambdaClient.CrudOperations", fixture.TestCrudOperations)
+}
+
+func TestDummyLambdaClientWithSharedSessionProvider(t *testing.T) {
+	function := testcont.NewDummyLambdaFunction()
+	emulator := openLambdaEmulator(t, function.LambdaFunction)
+	defer function.Close("")
+	defer emulator.Close("")
+
+	config := newEmulatorClientConfig(emulator)
+
+	provider := awscon.NewAwsSessionProvider()
+	provider.Configure(config)
+	err := provider.Open("")
+	assert.Nil(t, err)
+	defer provider.Close("")
+
+	client := NewDummyLambdaClient()
+	client.Configure(config)
+	client.SetReferences(cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services", "session-provider", "aws", "default", "1.0"), provider,
+	))
+	err = client.Open("")
+	assert.Nil(t, err)
+	assert.True(t, client.SessionProvider == provider)
+
+	fixture := awstest.NewDummyClientFixture(client)
+	t.Run("DummyLambdaClient.CrudOperations", fixture.TestCrudOperations)
+
+	// Shared provider stays opened after the client is closed
+	client.Close("")
+	assert.True(t, provider.IsOpen())
+}
+
+func TestDummyLambdaClientQualifier(t *testing.T) {
+	function := testcont.NewDummyLambdaFunction()
+	emulator := openLambdaEmulator(t, function.LambdaFunction)
+	defer function.Close("")
+	defer emulator.Close("")
+
+	// Qualifier is taken from the ARN
+	config := newEmulatorClientConfig(emulator)
+	config.SetAsObject("connection.arn", "arn:aws:lambda:us-east-1:000000000000:function:dummy:blue")
+
+	client := NewDummyLambdaClient()
+	client.Configure(config)
+	err := client.Open("")
+	assert.Nil(t, err)
+	defer client.Close("")
+
+	_, version, err := client.CallWithQualifier(nil, "", "get_dummies", "123", map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, "blue", version)
+
+	// Qualifier is overriden per call
+	_, version, err = client.CallWithQualifier(nil, "green", "get_dummies", "123", map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, "green", version)
+}
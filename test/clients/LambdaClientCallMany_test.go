@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	testcont "github.com/pip-services3-go/pip-services3-aws-go/test/container"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLambdaClientCallManyWithEmulator(t *testing.T) {
+	function := testcont.NewDummyLambdaFunction()
+	emulator := openLambdaEmulator(t, function.LambdaFunction)
+	defer function.Close("")
+	defer emulator.Close("")
+
+	client := NewDummyLambdaClient()
+	client.Configure(newEmulatorClientConfig(emulator))
+	err := client.Open("")
+	assert.Nil(t, err)
+	defer client.Close("")
+
+	calls := make([]*awsclient.LambdaCall, 0)
+	for _, key := range []string{"Key 1", "Key 2", "Key 3"} {
+		calls = append(calls, awsclient.NewLambdaCall(dummyType, "create_dummy", map[string]interface{}{
+			"dummy": awstest.Dummy{Key: key, Content: "Content"},
+		}))
+	}
+
+	results, err := client.CallMany(context.Background(), "123", calls, true)
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	for index, result := range results {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, calls[index].Params["dummy"].(awstest.Dummy).Key, result.Result.(*awstest.Dummy).Key)
+	}
+}
+
+func TestLambdaClientCallManyConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		var params map[string]interface{}
+		json.Unmarshal(body, &params)
+		result, _ := json.Marshal(params["key"])
+		w.Write(result)
+	}))
+	defer server.Close()
+
+	client := newRetryingClient(t, server, "options.max_concurrency", 2)
+	defer client.Close("")
+
+	calls := make([]*awsclient.LambdaCall, 0)
+	for _, key := range []string{"1", "2", "3", "4", "5"} {
+		calls = append(calls, awsclient.NewLambdaCall(nil, "get_dummy_by_id", map[string]interface{}{"key": key}))
+	}
+
+	results, err := client.CallMany(context.Background(), "123", calls, false)
+	assert.Nil(t, err)
+	for index, result := range results {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, calls[index].Params["key"], string(result.Result.([]byte)))
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestLambdaClientCallManyFailFast(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(10, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server, "options.max_concurrency", 1)
+	defer client.Close("")
+
+	items := []*awsclient.LambdaCall{
+		awsclient.NewLambdaCall(nil, "get_dummies", nil),
+		awsclient.NewLambdaCall(nil, "get_dummies", nil),
+		awsclient.NewLambdaCall(nil, "get_dummies", nil),
+	}
+
+	// Collect-all mode executes all calls
+	results, err := client.CallMany(context.Background(), "123", items, false)
+	assert.Nil(t, err)
+	for _, result := range results {
+		assert.Equal(t, "TOO_MANY_REQUESTS", result.Err.(*cerr.ApplicationError).Code)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// Fail-fast mode cancels calls after the first error
+	atomic.StoreInt32(&calls, 0)
+	results, err = client.CallMany(context.Background(), "123", items, true)
+	assert.NotNil(t, err)
+	assert.Equal(t, "TOO_MANY_REQUESTS", err.(*cerr.ApplicationError).Code)
+	assert.Equal(t, "CALL_CANCELED", results[2].Err.(*cerr.ApplicationError).Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	awsbuild "github.com/pip-services3-go/pip-services3-aws-go/build"
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	awscont "github.com/pip-services3-go/pip-services3-aws-go/container"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	testcont "github.com/pip-services3-go/pip-services3-aws-go/test/container"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaClientClaimCheck(t *testing.T) {
+	s3 := awstest.NewMemoryS3Server()
+	defer s3.Close()
+
+	function := testcont.NewDummyLambdaFunction()
+	function.AddFactory(awsbuild.NewDefaultAwsFactory())
+	function.Configure(cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+		"claim_check.descriptor", "pip-services:claim-check:s3:default:1.0",
+		"claim_check.bucket", "payloads",
+		"claim_check.threshold", 1024,
+		"claim_check.endpoint", s3.URL,
+		"claim_check.connection.region", "us-east-1",
+		"claim_check.credential.access_id", "test",
+		"claim_check.credential.access_key", "test",
+	))
+	err := function.Open("")
+	assert.Nil(t, err)
+	defer function.Close("")
+
+	emulator := awscont.NewLambdaEmulator(function.LambdaFunction)
+	emulator.Configure(cconf.NewConfigParamsFromTuples("connection.port", 0))
+	err = emulator.Open("")
+	assert.Nil(t, err)
+	defer emulator.Close("")
+
+	config := newEmulatorClientConfig(emulator)
+	client := NewDummyLambdaClient()
+	client.Configure(config.Override(cconf.NewConfigParamsFromTuples(
+		"options.claim_check.bucket", "payloads",
+		"options.claim_check.threshold", 1024,
+		"options.claim_check.endpoint", s3.URL,
+	)))
+	err = client.Open("")
+	assert.Nil(t, err)
+	defer client.Close("")
+
+	// Large request and response are passed via S3
+	content := strings.Repeat("Large content ", 1000)
+	dummy, err := client.CreateDummy("123", awstest.Dummy{Key: "Key 1", Content: content})
+	assert.Nil(t, err)
+	assert.Equal(t, content, dummy.Content)
+	assert.Equal(t, 2, s3.PutCount())
+
+	// Small requests are passed directly
+	dummy, err = client.GetDummyById("123", dummy.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, content, dummy.Content)
+	assert.Equal(t, 3, s3.PutCount())
+
+	assert.Equal(t, 0, s3.ObjectCount())
+}
+
+func TestLambdaClientRejectsTooLargePayloads(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(0, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server)
+	defer client.Close("")
+
+	err := client.CallOneWay(nil, "create_dummy", "123", map[string]interface{}{
+		"content": strings.Repeat("x", 300*1024),
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", err.(*cerr.ApplicationError).Code)
+	assert.Equal(t, int32(0), calls)
+}
+
+func TestLambdaClientClaimCheckOneWay(t *testing.T) {
+	s3 := awstest.NewMemoryS3Server()
+	defer s3.Close()
+
+	var size int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		size = len(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := newRetryingClient(t, server,
+		"options.claim_check.bucket", "payloads",
+		"options.claim_check.endpoint", s3.URL,
+	)
+	defer client.Close("")
+
+	// Requests above async limit are passed via S3 even below the threshold
+	err := client.CallOneWay(nil, "create_dummy", "123", map[string]interface{}{
+		"content": strings.Repeat("x", 300*1024),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, s3.PutCount())
+	assert.True(t, size < 1024)
+}
+
+func TestLambdaClientClaimCheckRetries(t *testing.T) {
+	s3 := awstest.NewMemoryS3Server()
+	defer s3.Close()
+
+	claimCheck := awscon.NewS3ClaimCheck()
+	claimCheck.Configure(cconf.NewConfigParamsFromTuples(
+		"bucket", "payloads",
+		"endpoint", s3.URL,
+		"connection.region", "us-east-1",
+		"credential.access_id", "test",
+		"credential.access_key", "test",
+	))
+	err := claimCheck.Open("")
+	assert.Nil(t, err)
+	defer claimCheck.Close("")
+
+	// The function reads and deletes the request, and fails on the first call
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+
+		reference := awscon.ParseClaimCheckReference(request)
+		if _, err := claimCheck.Get(r.Context(), "", reference); err != nil {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(awscont.NewErrorEnvelope("", err).ToJson()))
+			return
+		}
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("X-Amzn-Errortype", "TooManyRequestsException")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"Rate exceeded"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := newRetryingClient(t, server,
+		"options.retry_commands", "update_dummy",
+		"options.claim_check.bucket", "payloads",
+		"options.claim_check.threshold", 1024,
+		"options.claim_check.endpoint", s3.URL,
+	)
+	defer client.Close("")
+
+	result, err := client.Call(nil, "update_dummy", "123", map[string]interface{}{
+		"content": strings.Repeat("x", 2048),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(result.([]byte)))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, s3.PutCount())
+	assert.Equal(t, 0, s3.ObjectCount())
+}
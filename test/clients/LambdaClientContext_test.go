@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLambdaClientCallWithContext(t *testing.T) {
+	var traceId string
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceId = r.Header.Get(awsclient.TraceHeader)
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := newRetryingClient(t, server)
+	defer client.Close("")
+
+	ctx := awsclient.ContextWithCorrelationId(context.Background(), "123")
+	ctx = awsclient.ContextWithTraceId(ctx, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1")
+
+	result, err := client.CallWithContext(ctx, nil, "get_dummies", "", map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(result.([]byte)))
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", traceId)
+	assert.Equal(t, "123", payload["correlation_id"])
+}
+
+func TestLambdaClientCallWithDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := newRetryingClient(t, server)
+	defer client.Close("")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.CallWithContext(ctx, nil, "get_dummies", "123", map[string]interface{}{})
+	assert.True(t, time.Since(start) < time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, "CALL_TIMEOUT", err.(*cerr.ApplicationError).Code)
+
+	// Canceled context fails the call without invocation
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = client.CallOneWayWithContext(ctx, nil, "get_dummies", "123", map[string]interface{}{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "CALL_CANCELED", err.(*cerr.ApplicationError).Code)
+}
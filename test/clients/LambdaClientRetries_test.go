@@ -0,0 +1,123 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// Starts Lambda API stand-in that throttles the first calls
+func newThrottlingServer(throttledCalls int32, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= throttledCalls {
+			w.Header().Set("X-Amzn-Errortype", "TooManyRequestsException")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"Rate exceeded"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+}
+
+func newRetryingClient(t *testing.T, server *httptest.Server, options ...interface{}) *awsclient.LambdaClient {
+	config := cconf.NewConfigParamsFromTuples(
+		"connection.arn", "arn:aws:lambda:us-east-1:000000000000:function:dummy",
+		"connection.endpoint", server.URL,
+		"credential.access_id", "test",
+		"credential.access_key", "test",
+		"options.max_retries", 0,
+		"options.retry_min_timeout", 1,
+		"options.retry_max_timeout", 5,
+	)
+	config = config.Override(cconf.NewConfigParamsFromTuples(options...))
+
+	client := awsclient.NewLambdaClient()
+	client.Configure(config)
+	err := client.Open("")
+	assert.Nil(t, err)
+	return client
+}
+
+func TestLambdaClientRetriesIdempotentCommands(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(2, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server, "options.retry_commands", "get_dummy_by_id")
+	defer client.Close("")
+
+	result, err := client.Call(nil, "get_dummy_by_id", "123", map[string]interface{}{"dummy_id": "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(result.([]byte)))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestLambdaClientStopsAfterRetries(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(10, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server, "options.retry_commands", "*", "options.retries", 2)
+	defer client.Close("")
+
+	_, err := client.Call(nil, "get_dummy_by_id", "123", map[string]interface{}{"dummy_id": "1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "TOO_MANY_REQUESTS", err.(*cerr.ApplicationError).Code)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestLambdaClientDoesNotRetryNonIdempotentCommands(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(1, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server, "options.retry_commands", "get_dummy_by_id")
+	defer client.Close("")
+
+	_, err := client.Call(nil, "create_dummy", "123", map[string]interface{}{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestLambdaClientDoesNotRetryOneWayCalls(t *testing.T) {
+	var calls int32
+	server := newThrottlingServer(1, &calls)
+	defer server.Close()
+
+	client := newRetryingClient(t, server, "options.retry_commands", "*")
+	defer client.Close("")
+
+	err := client.CallOneWay(nil, "get_dummy_by_id", "123", map[string]interface{}{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := awsclient.NewRetryPolicy()
+	policy.Configure(cconf.NewConfigParamsFromTuples(
+		"options.retry_min_timeout", 100,
+		"options.retry_max_timeout", 300,
+		"options.retry_jitter", false,
+	))
+	policy.AddIdempotentCommands("get_dummies")
+
+	assert.True(t, policy.CanRetry("get_dummies", false))
+	assert.False(t, policy.CanRetry("get_dummies", true))
+	assert.False(t, policy.CanRetry("create_dummy", false))
+
+	assert.Equal(t, int64(100), policy.GetTimeout(1).Milliseconds())
+	assert.Equal(t, int64(200), policy.GetTimeout(2).Milliseconds())
+	assert.Equal(t, int64(300), policy.GetTimeout(3).Milliseconds())
+
+	assert.True(t, policy.IsRetryable(cerr.NewConnectionError("123", "CONNECTION_FAILED", "Connection failed")))
+	assert.True(t, policy.IsRetryable(cerr.NewInvocationError("123", "TOO_MANY_REQUESTS", "Throttled")))
+	assert.False(t, policy.IsRetryable(cerr.NewBadRequestError("123", "PAYLOAD_TOO_LARGE", "Too large")))
+	assert.False(t, policy.IsRetryable(cerr.NewInvocationError("123", "UNHANDLED_ERROR", "Failed").WithStatus(500)))
+}
@@ -0,0 +1,16 @@
+// Generates DummyGeneratedLambdaClient from DummyCommandSet commands.
+package main
+
+import (
+	"log"
+
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+)
+
+func main() {
+	commandSet := awstest.NewDummyCommandSet(nil)
+	err := awstest.NewDummyClientGenerator().GenerateFile(&commandSet.CommandSet, "DummyGeneratedLambdaClient.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"testing"
+
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwsArn(t *testing.T) {
+	// Lambda function with alias
+	arn, err := awscon.ParseAwsArn("123", "arn:aws:lambda:us-east-1:123456789012:function:pip-services-dummies:prod")
+	assert.Nil(t, err)
+	assert.Equal(t, "lambda", arn.Service)
+	assert.Equal(t, "function", arn.ResourceType)
+	assert.Equal(t, "pip-services-dummies", arn.Resource)
+	assert.Equal(t, "prod", arn.Qualifier)
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:pip-services-dummies", arn.Unqualified())
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:pip-services-dummies:prod", arn.String())
+
+	// CloudWatch log group
+	arn, err = awscon.ParseAwsArn("123", "arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/dummies:*")
+	assert.Nil(t, err)
+	assert.Equal(t, "log-group", arn.ResourceType)
+	assert.Equal(t, "/aws/lambda/dummies:*", arn.Resource)
+	assert.Equal(t, "", arn.Qualifier)
+
+	// SQS queue
+	arn, err = awscon.ParseAwsArn("123", "arn:aws:sqs:us-east-1:123456789012:dummies.fifo")
+	assert.Nil(t, err)
+	assert.Equal(t, "", arn.ResourceType)
+	assert.Equal(t, "dummies.fifo", arn.Resource)
+
+	// Resource with slash delimiter
+	arn, err = awscon.ParseAwsArn("123", "arn:aws:dynamodb:us-east-1:123456789012:table/dummies/stream/2020")
+	assert.Nil(t, err)
+	assert.Equal(t, "table", arn.ResourceType)
+	assert.Equal(t, "dummies/stream/2020", arn.Resource)
+	assert.Equal(t, "arn:aws:dynamodb:us-east-1:123456789012:table/dummies/stream/2020", arn.String())
+
+	// Custom regions of local emulators are accepted
+	arn, err = awscon.ParseAwsArn("123", "arn:aws:sqs:local:000000000000:dummies")
+	assert.Nil(t, err)
+	assert.Equal(t, "local", arn.Region)
+
+	// Malformed ARNs return ConfigError
+	for _, value := range []string{
+		"",
+		"arn:aws:lambda",
+		"not:aws:lambda:us-east-1:123456789012:function:dummies",
+		"arn:aws:lambda:us-east-1::function:dummies",
+		"arn:aws:lambda:us-east-1:123456789012:function:bad name",
+		"arn:aws:lambda:us-east-1:123456789012:function:dummies:bad.alias",
+		"arn:aws:logs:us-east-1:123456789012:stream:dummies",
+	} {
+		_, err = awscon.ParseAwsArn("123", value)
+		assert.NotNil(t, err, value)
+		appErr, ok := err.(*cerr.ApplicationError)
+		assert.True(t, ok)
+		assert.Equal(t, "INVALID_ARN", appErr.Code)
+		assert.Equal(t, cerr.Misconfiguration, appErr.Category)
+	}
+}
+
+func TestAwsConnectionParamsArn(t *testing.T) {
+	// Malformed ARN does not panic and fails validation
+	connection := awscon.NewAwsConnectionParamsFromConfig(cconf.NewConfigParamsFromTuples(
+		"connection.arn", "arn:aws:lambda",
+	))
+	connection.SetArn("arn:aws:lambda")
+	err := connection.Validate("123")
+	assert.NotNil(t, err)
+	assert.Equal(t, "INVALID_ARN", err.Code)
+
+	// Qualifier is taken from the ARN
+	connection.SetArn("arn:aws:lambda:us-east-1:123456789012:function:dummies:2")
+	assert.Nil(t, connection.Validate("123"))
+	assert.Equal(t, "dummies", connection.GetResource())
+	assert.Equal(t, "2", connection.GetQualifier())
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>role_id</AccessKeyId>
+      <SecretAccessKey>role_key</SecretAccessKey>
+      <SessionToken>role_token</SessionToken>
+      <Expiration>2100-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/dummy/pip-services</Arn>
+      <AssumedRoleId>ARO123:pip-services</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+  <ResponseMetadata>
+    <RequestId>1</RequestId>
+  </ResponseMetadata>
+</AssumeRoleResponse>`
+
+func TestAwsAssumeRole(t *testing.T) {
+	var form map[string]string
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = map[string]string{
+			"Action":          r.FormValue("Action"),
+			"RoleArn":         r.FormValue("RoleArn"),
+			"ExternalId":      r.FormValue("ExternalId"),
+			"RoleSessionName": r.FormValue("RoleSessionName"),
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(assumeRoleResponse))
+	}))
+	defer sts.Close()
+
+	resolver := awscon.NewAwsConnectionResolver()
+	resolver.Configure(cconf.NewConfigParamsFromTuples(
+		"connection.region", "us-east-1",
+		"connection.sts_endpoint", sts.URL,
+		"credential.access_id", "test",
+		"credential.access_key", "test",
+		"credential.session_token", "token",
+		"credential.role_arn", "arn:aws:iam::123456789012:role/dummy",
+		"credential.external_id", "external",
+		"credential.role_session_name", "pip-services",
+	))
+	connection, err := resolver.Resolve("123")
+	assert.Nil(t, err)
+	assert.Equal(t, "token", connection.GetSessionToken())
+	assert.Equal(t, "arn:aws:iam::123456789012:role/dummy", connection.GetRoleArn())
+
+	sess, err := awscon.NewAwsSession("123", connection, 0)
+	assert.Nil(t, err)
+
+	value, err := sess.Config.Credentials.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "role_id", value.AccessKeyID)
+	assert.Equal(t, "role_key", value.SecretAccessKey)
+	assert.Equal(t, "role_token", value.SessionToken)
+
+	assert.Equal(t, "AssumeRole", form["Action"])
+	assert.Equal(t, "arn:aws:iam::123456789012:role/dummy", form["RoleArn"])
+	assert.Equal(t, "external", form["ExternalId"])
+	assert.Equal(t, "pip-services", form["RoleSessionName"])
+}
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwsDefaultCredentials(t *testing.T) {
+	// Static credentials are optional
+	resolver := awscon.NewAwsConnectionResolver()
+	resolver.Configure(cconf.NewConfigParamsFromTuples(
+		"connection.region", "us-east-1",
+		"credential.profile", "dev",
+	))
+	connection, err := resolver.Resolve("123")
+	assert.Nil(t, err)
+	assert.False(t, connection.UseStaticCredentials())
+	assert.Equal(t, "dev", connection.GetProfile())
+
+	// Static credentials must be set in pairs
+	resolver = awscon.NewAwsConnectionResolver()
+	resolver.Configure(cconf.NewConfigParamsFromTuples(
+		"connection.region", "us-east-1",
+		"credential.access_id", "test",
+	))
+	_, err = resolver.Resolve("123")
+	assert.NotNil(t, err)
+	assert.Equal(t, "NO_ACCESS_KEY", err.(*cerr.ApplicationError).Code)
+
+	// Credentials with store key must be found
+	resolver = awscon.NewAwsConnectionResolver()
+	resolver.Configure(cconf.NewConfigParamsFromTuples(
+		"connection.region", "us-east-1",
+		"credential.store_key", "aws",
+	))
+	_, err = resolver.Resolve("123")
+	assert.NotNil(t, err)
+	assert.Equal(t, "NO_CREDENTIAL", err.(*cerr.ApplicationError).Code)
+
+	// Session takes credentials from the default provider chain
+	os.Setenv("AWS_ACCESS_KEY_ID", "env_id")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "env_key")
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+	connection = awscon.NewAwsConnectionParamsFromConfig(cconf.NewConfigParamsFromTuples(
+		"connection.region", "us-east-1",
+	))
+	sess, err := awscon.NewAwsSession("123", connection, 3)
+	assert.Nil(t, err)
+
+	value, err := sess.Config.Credentials.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "env_id", value.AccessKeyID)
+	assert.Equal(t, "env_key", value.SecretAccessKey)
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwsSessionProvider(t *testing.T) {
+	provider := awscon.NewAwsSessionProvider()
+	provider.Configure(cconf.NewConfigParamsFromTuples(
+		"connection.region", "us-east-1",
+		"connection.endpoint", "http://localhost:4566",
+		"credential.access_id", "test",
+		"credential.access_key", "test",
+		"options.max_retries", 5,
+		"options.connect_timeout", 5000,
+		"options.max_connections", 10,
+	))
+
+	// Sessions are not available before opening
+	_, err := provider.GetSession("123")
+	assert.NotNil(t, err)
+
+	err = provider.Open("123")
+	assert.Nil(t, err)
+	defer provider.Close("123")
+
+	assert.Equal(t, "us-east-1", provider.GetConnection().GetRegion())
+
+	sess, err := provider.GetSession("123")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, *sess.Config.MaxRetries)
+	assert.Equal(t, "http://localhost:4566", *sess.Config.Endpoint)
+	assert.Equal(t, 5*time.Second, sess.Config.HTTPClient.Timeout)
+
+	// Sessions and clients are cached
+	sess1, _ := provider.GetSession("123")
+	assert.True(t, sess == sess1)
+
+	factory := func(sess *session.Session) interface{} {
+		return lambda.New(sess)
+	}
+	client, err := provider.GetClient("123", "lambda", factory)
+	assert.Nil(t, err)
+	client1, _ := provider.GetClient("123", "lambda", factory)
+	assert.True(t, client == client1)
+}
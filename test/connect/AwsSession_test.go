@@ -0,0 +1,44 @@
+package test
+
+import (
+	"testing"
+
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwsSession(t *testing.T) {
+	connection := awscon.NewAwsConnectionParamsFromConfig(
+		cconf.NewConfigParamsFromTuples(
+			"connection.region", "us-east-1",
+			"connection.endpoint", "http://localhost:4566",
+			"connection.s3_force_path_style", true,
+			"connection.disable_ssl", true,
+			"credential.access_id", "test",
+			"credential.access_key", "test",
+		))
+
+	assert.Equal(t, "http://localhost:4566", connection.GetEndpoint())
+	assert.True(t, connection.GetS3ForcePathStyle())
+	assert.True(t, connection.GetDisableSsl())
+	assert.Equal(t, "", connection.GetCaBundle())
+
+	sess, err := awscon.NewAwsSession("123", connection, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:4566", *sess.Config.Endpoint)
+	assert.Equal(t, "us-east-1", *sess.Config.Region)
+	assert.True(t, *sess.Config.S3ForcePathStyle)
+	assert.True(t, *sess.Config.DisableSSL)
+	assert.Equal(t, 3, *sess.Config.MaxRetries)
+
+	// Missing CA bundle is a configuration error
+	connection.SetCaBundle("/not/existing/ca.pem")
+	_, err = awscon.NewAwsSession("123", connection, 3)
+	assert.NotNil(t, err)
+	appErr, ok := err.(*cerr.ApplicationError)
+	assert.True(t, ok)
+	assert.Equal(t, "CANNOT_READ_CA_BUNDLE", appErr.Code)
+	assert.Equal(t, cerr.Misconfiguration, appErr.Category)
+}
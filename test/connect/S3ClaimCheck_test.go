@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	awscon "github.com/pip-services3-go/pip-services3-aws-go/connect"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3ClaimCheck(t *testing.T) {
+	server := awstest.NewMemoryS3Server()
+	defer server.Close()
+
+	claimCheck := awscon.NewS3ClaimCheck()
+	assert.False(t, claimCheck.IsEnabled())
+
+	claimCheck.Configure(cconf.NewConfigParamsFromTuples(
+		"bucket", "payloads",
+		"threshold", 10,
+		"endpoint", server.URL,
+		"connection.region", "us-east-1",
+		"credential.access_id", "test",
+		"credential.access_key", "test",
+	))
+	assert.True(t, claimCheck.IsEnabled())
+	assert.False(t, claimCheck.NeedsOffload(10))
+	assert.True(t, claimCheck.NeedsOffload(11))
+
+	err := claimCheck.Open("123")
+	assert.Nil(t, err)
+	defer claimCheck.Close("123")
+
+	payload := []byte(`{"cmd":"create_dummy","dummy":{"content":"Large content"}}`)
+	reference, err := claimCheck.Put(context.Background(), "123", payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "payloads", reference.Bucket)
+	assert.Contains(t, reference.Key, "claim-check/")
+	assert.Equal(t, len(payload), reference.Size)
+	assert.Equal(t, 1, server.ObjectCount())
+
+	parsed := awscon.ParseClaimCheckReference(map[string]interface{}{
+		"claim_check": map[string]interface{}{
+			"bucket": reference.Bucket,
+			"key":    reference.Key,
+			"size":   float64(reference.Size),
+		},
+	})
+	assert.Equal(t, reference, parsed)
+
+	// Objects are deleted after read
+	result, err := claimCheck.Get(context.Background(), "123", parsed)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, result)
+	assert.Equal(t, 0, server.ObjectCount())
+
+	_, err = claimCheck.Get(context.Background(), "123", parsed)
+	assert.NotNil(t, err)
+	assert.Equal(t, "CLAIM_CHECK_GET_FAILED", err.(*cerr.ApplicationError).Code)
+
+	// Objects outside of configured location are not read
+	_, err = claimCheck.Get(context.Background(), "123", &awscon.ClaimCheckReference{Bucket: "other", Key: "secret"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "INVALID_CLAIM_CHECK", err.(*cerr.ApplicationError).Code)
+}
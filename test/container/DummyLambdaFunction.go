@@ -95,34 +95,34 @@ func (c *DummyLambdaFunction) deleteById(params map[string]interface{}) (interfa
 
 func (c *DummyLambdaFunction) Register() {
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"get_dummies",
-		&cvalid.NewObjectSchema().
+		cvalid.NewObjectSchema().
 			WithOptionalProperty("filter", cvalid.NewFilterParamsSchema()).
-			WithOptionalProperty("paging", cvalid.NewPagingParamsSchema()).Schema,
+			WithOptionalProperty("paging", cvalid.NewPagingParamsSchema()),
 		c.getPageByFilter)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"get_dummy_by_id",
-		&cvalid.NewObjectSchema().
-			WithOptionalProperty("dummy_id", cconv.String).Schema,
+		cvalid.NewObjectSchema().
+			WithOptionalProperty("dummy_id", cconv.String),
 		c.getOneById)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"create_dummy",
-		&cvalid.NewObjectSchema().
-			WithRequiredProperty("dummy", awstest.NewDummySchema()).Schema,
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy", awstest.NewDummySchema()),
 		c.create)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"update_dummy",
-		&cvalid.NewObjectSchema().
-			WithRequiredProperty("dummy", awstest.NewDummySchema()).Schema,
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy", awstest.NewDummySchema()),
 		c.update)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"delete_dummy",
-		&cvalid.NewObjectSchema().
-			WithOptionalProperty("dummy_id", cconv.String).Schema,
+		cvalid.NewObjectSchema().
+			WithOptionalProperty("dummy_id", cconv.String),
 		c.deleteById)
 }
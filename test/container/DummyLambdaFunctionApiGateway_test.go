@@ -0,0 +1,242 @@
+package test_container
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func toEventMap(t *testing.T, event interface{}) map[string]interface{} {
+	data, err := json.Marshal(event)
+	assert.Nil(t, err)
+	result := make(map[string]interface{})
+	err = json.Unmarshal(data, &result)
+	assert.Nil(t, err)
+	return result
+}
+
+func openDummyLambdaFunction(t *testing.T) *DummyLambdaFunction {
+	restConfig := cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+	)
+
+	ctrl := awstest.NewDummyController()
+
+	lambda := NewDummyLambdaFunction()
+	lambda.Configure(restConfig)
+
+	var references *cref.References = cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), ctrl,
+	)
+	lambda.SetReferences(references)
+	opnErr := lambda.Open("")
+	assert.Nil(t, opnErr)
+
+	lambda.RegisterRoute("POST", "/dummies", "create_dummy")
+	lambda.RegisterRoute("GET", "/dummies/{dummy_id}", "get_dummy_by_id")
+	lambda.RegisterRoute("DELETE", "/dummies/{dummy_id}", "delete_dummy")
+	return lambda
+}
+
+func TestDummyLambdaFunctionApiGateway(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	// Create one dummy
+	body, _ := json.Marshal(map[string]interface{}{
+		"dummy": awstest.Dummy{Id: "", Key: "Key 1", Content: "Content 1"},
+	})
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/dummies",
+		Body:       string(body),
+	}))
+	assert.Nil(t, err)
+
+	response, ok := res.(events.APIGatewayProxyResponse)
+	assert.True(t, ok)
+	assert.Equal(t, 200, response.StatusCode)
+
+	var dummy awstest.Dummy
+	jsonErr := json.Unmarshal([]byte(response.Body), &dummy)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, "Content 1", dummy.Content)
+	assert.Equal(t, "Key 1", dummy.Key)
+
+	// Get the dummy by path parameter
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/dummies/" + dummy.Id,
+	}))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayProxyResponse)
+	assert.Equal(t, 200, response.StatusCode)
+
+	var dummy1 awstest.Dummy
+	jsonErr = json.Unmarshal([]byte(response.Body), &dummy1)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, dummy.Id, dummy1.Id)
+
+	// Actions without registered routes are not exposed
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/get_dummies",
+		QueryStringParameters: map[string]string{"correlation_id": "123"},
+	}))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayProxyResponse)
+	assert.Equal(t, 404, response.StatusCode)
+
+	// Fail validation
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/dummies",
+		Body:       "{}",
+	}))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayProxyResponse)
+	assert.Equal(t, 400, response.StatusCode)
+
+	var description cerr.ErrorDescription
+	jsonErr = json.Unmarshal([]byte(response.Body), &description)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, cerr.BadRequest, description.Category)
+
+	// Try unknown route
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.APIGatewayProxyRequest{
+		HTTPMethod: "PUT",
+		Path:       "/unknown",
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID: "abc",
+		},
+	}))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayProxyResponse)
+	assert.Equal(t, 404, response.StatusCode)
+
+	jsonErr = json.Unmarshal([]byte(response.Body), &description)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, "NO_ROUTE", description.Code)
+	assert.Equal(t, "abc", description.CorrelationId)
+}
+
+func TestDummyLambdaFunctionApiGatewayV2(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	// Create one dummy with base64 encoded body
+	body, _ := json.Marshal(map[string]interface{}{
+		"dummy": awstest.Dummy{Id: "", Key: "Key 1", Content: "Content 1"},
+	})
+	request := events.APIGatewayV2HTTPRequest{
+		Version:         "2.0",
+		RouteKey:        "POST /dummies",
+		RawPath:         "/dummies",
+		Body:            base64.StdEncoding.EncodeToString(body),
+		IsBase64Encoded: true,
+		Cookies:         []string{"session=1"},
+	}
+	request.RequestContext.HTTP.Method = "POST"
+
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, request))
+	assert.Nil(t, err)
+
+	response, ok := res.(events.APIGatewayV2HTTPResponse)
+	assert.True(t, ok)
+	assert.Equal(t, 200, response.StatusCode)
+
+	var dummy awstest.Dummy
+	jsonErr := json.Unmarshal([]byte(response.Body), &dummy)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, "Content 1", dummy.Content)
+
+	// Get the dummy via Function URL with catch-all route
+	request = events.APIGatewayV2HTTPRequest{
+		Version:  "2.0",
+		RouteKey: "$default",
+		RawPath:  "/dummies/" + dummy.Id,
+	}
+	request.RequestContext.HTTP.Method = "GET"
+
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, request))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayV2HTTPResponse)
+	assert.Equal(t, 200, response.StatusCode)
+
+	var dummy1 awstest.Dummy
+	jsonErr = json.Unmarshal([]byte(response.Body), &dummy1)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, dummy.Id, dummy1.Id)
+
+	// Delete the dummy with path parameters resolved by API Gateway
+	request = events.APIGatewayV2HTTPRequest{
+		Version:        "2.0",
+		RouteKey:       "DELETE /dummies/{dummy_id}",
+		RawPath:        "/v1/dummies/" + dummy.Id,
+		PathParameters: map[string]string{"dummy_id": dummy.Id},
+	}
+	request.RequestContext.HTTP.Method = "DELETE"
+
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, request))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayV2HTTPResponse)
+	assert.Equal(t, 200, response.StatusCode)
+}
+
+func TestDummyLambdaFunctionApiGatewayV2EncodedPath(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	// Create dummy with id that has to be encoded in URL
+	body, _ := json.Marshal(map[string]interface{}{
+		"dummy": awstest.Dummy{Id: "a b", Key: "Key 1", Content: "Content 1"},
+	})
+	request := events.APIGatewayV2HTTPRequest{
+		Version:  "2.0",
+		RouteKey: "POST /dummies",
+		RawPath:  "/dummies",
+		Body:     string(body),
+	}
+	request.RequestContext.HTTP.Method = "POST"
+
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, request))
+	assert.Nil(t, err)
+
+	response := res.(events.APIGatewayV2HTTPResponse)
+	assert.Equal(t, 200, response.StatusCode)
+
+	// Get the dummy via Function URL with encoded path segment
+	request = events.APIGatewayV2HTTPRequest{
+		Version:  "2.0",
+		RouteKey: "$default",
+		RawPath:  "/dummies/a%20b",
+	}
+	request.RequestContext.HTTP.Method = "GET"
+	request.RequestContext.HTTP.Path = "/dummies/a b"
+
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, request))
+	assert.Nil(t, err)
+
+	response = res.(events.APIGatewayV2HTTPResponse)
+	assert.Equal(t, 200, response.StatusCode)
+
+	var dummy awstest.Dummy
+	jsonErr := json.Unmarshal([]byte(response.Body), &dummy)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, "a b", dummy.Id)
+}
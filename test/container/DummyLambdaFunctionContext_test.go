@@ -0,0 +1,58 @@
+package test_container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaFunctionContext(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	var requestId string
+	var hasDeadline bool
+	err := lambda.RegisterActionWithContext("get_request_id", nil,
+		func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			if lc, ok := lambdacontext.FromContext(ctx); ok {
+				requestId = lc.AwsRequestID
+			}
+			_, hasDeadline = ctx.Deadline()
+			return requestId, nil
+		})
+	assert.Nil(t, err)
+
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{AwsRequestID: "req-1"})
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	res, err := lambda.Handler(ctx, map[string]interface{}{
+		"cmd": "get_request_id",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "\"req-1\"", res)
+	assert.Equal(t, "req-1", requestId)
+	assert.True(t, hasDeadline)
+
+	// Registered actions can be called with and without context
+	for _, action := range lambda.GetActions() {
+		assert.NotNil(t, action.Action)
+		assert.NotNil(t, action.ActionWithContext)
+		if action.Cmd == "get_request_id" {
+			hasDeadline = true
+			_, err := action.Action(map[string]interface{}{})
+			assert.Nil(t, err)
+			assert.False(t, hasDeadline)
+		}
+	}
+
+	// Legacy actions keep working
+	res, err = lambda.Handler(ctx, map[string]interface{}{
+		"cmd": "get_dummies",
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, res)
+}
@@ -0,0 +1,40 @@
+package test_container
+
+import (
+	"context"
+	"testing"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaFunctionErrors(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	// Unknown action fails invocation with error envelope
+	_, err := lambda.Handler(context.TODO(), map[string]interface{}{
+		"cmd":            "unknown_action",
+		"correlation_id": "123",
+	})
+	assert.NotNil(t, err)
+
+	appErr := awsclient.ConvertErrorEnvelope([]byte(err.Error()))
+	assert.NotNil(t, appErr)
+	assert.Equal(t, "NO_ACTION", appErr.Code)
+	assert.Equal(t, cerr.BadRequest, appErr.Category)
+	assert.Equal(t, 400, appErr.Status)
+	assert.Equal(t, "123", appErr.CorrelationId)
+	assert.Equal(t, "unknown_action", appErr.Details["command"])
+
+	// Validation errors are restored by Act
+	_, err = lambda.Act(map[string]interface{}{
+		"cmd": "create_dummy",
+	})
+	assert.NotNil(t, err)
+
+	appErr, ok := err.(*cerr.ApplicationError)
+	assert.True(t, ok)
+	assert.Equal(t, cerr.BadRequest, appErr.Category)
+}
@@ -0,0 +1,73 @@
+package test_container
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaFunctionEventRoutesDoNotCrossMatch(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	calls := make([]string, 0)
+	register := func(cmd string) {
+		lambda.RegisterAction(cmd, nil, func(params map[string]interface{}) (interface{}, error) {
+			calls = append(calls, cmd)
+			return nil, nil
+		})
+	}
+	register("on_s3_object")
+	register("on_bridge_event")
+
+	// Both routes use the same source
+	err := lambda.RegisterS3EventRoute("dummies", "ObjectCreated:*", "", "", "on_s3_object")
+	assert.Nil(t, err)
+	err = lambda.RegisterEventRoute("dummies", "", "on_bridge_event")
+	assert.Nil(t, err)
+
+	record := events.S3EventRecord{EventSource: "aws:s3", EventName: "ObjectCreated:Put"}
+	record.S3.Bucket.Name = "dummies"
+	record.S3.Bucket.Arn = "arn:aws:s3:::dummies"
+	record.S3.Object.Key = "dummy.json"
+
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.S3Event{Records: []events.S3EventRecord{record}}))
+	assert.Nil(t, err)
+
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.CloudWatchEvent{
+		ID:         "1",
+		DetailType: "Dummy Changed",
+		Source:     "dummies",
+		Time:       time.Now(),
+		Detail:     json.RawMessage("{}"),
+	}))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"on_s3_object", "on_bridge_event"}, calls)
+
+	// S3 routes do not match events from other sources, and vice versa
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.CloudWatchEvent{
+		ID:         "2",
+		DetailType: "Object Created",
+		Source:     "aws.s3",
+		Time:       time.Now(),
+		Resources:  []string{"arn:aws:s3:::dummies"},
+		Detail:     json.RawMessage("{}"),
+	}))
+	assert.NotNil(t, err)
+	assert.Equal(t, "NO_EVENT_ROUTE", err.(*cerr.ApplicationError).Code)
+
+	record.S3.Bucket.Name = "other"
+	record.S3.Bucket.Arn = "arn:aws:s3:::other"
+	lambda.RegisterEventRoute("*", "", "on_bridge_event")
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, events.S3Event{Records: []events.S3EventRecord{record}}))
+	assert.NotNil(t, err)
+	assert.Equal(t, "NO_EVENT_ROUTE", err.(*cerr.ApplicationError).Code)
+
+	assert.Len(t, calls, 2)
+}
@@ -0,0 +1,41 @@
+package test_container
+
+import (
+	"context"
+	"testing"
+
+	awsclient "github.com/pip-services3-go/pip-services3-aws-go/clients"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaFunctionPanic(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	err := lambda.RegisterAction("panic_action", nil,
+		func(params map[string]interface{}) (interface{}, error) {
+			panic("Something went wrong")
+		})
+	assert.Nil(t, err)
+
+	// Panic is returned as UnknownError
+	_, err = lambda.Handler(context.TODO(), map[string]interface{}{
+		"cmd":            "panic_action",
+		"correlation_id": "123",
+	})
+	assert.NotNil(t, err)
+
+	appErr := awsclient.ConvertErrorEnvelope([]byte(err.Error()))
+	assert.NotNil(t, appErr)
+	assert.Equal(t, "ACTION_PANIC", appErr.Code)
+	assert.Equal(t, cerr.Unknown, appErr.Category)
+	assert.Equal(t, "123", appErr.CorrelationId)
+	assert.NotEmpty(t, appErr.StackTrace)
+
+	// Container stays warm for the next request
+	_, err = lambda.Handler(context.TODO(), map[string]interface{}{
+		"cmd": "get_dummies",
+	})
+	assert.Nil(t, err)
+}
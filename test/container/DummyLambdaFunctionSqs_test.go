@@ -0,0 +1,67 @@
+package test_container
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	awscont "github.com/pip-services3-go/pip-services3-aws-go/container"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaFunctionSqs(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	body1, _ := json.Marshal(map[string]interface{}{
+		"cmd":   "create_dummy",
+		"dummy": awstest.Dummy{Id: "", Key: "Key 1", Content: "Content 1"},
+	})
+	body2, _ := json.Marshal(map[string]interface{}{
+		"cmd": "create_dummy",
+	})
+	correlationId := "123"
+
+	event := events.SQSEvent{
+		Records: []events.SQSMessage{
+			{
+				MessageId:   "1",
+				EventSource: "aws:sqs",
+				Body:        string(body1),
+				MessageAttributes: map[string]events.SQSMessageAttribute{
+					"correlation_id": {StringValue: &correlationId, DataType: "String"},
+				},
+			},
+			{
+				MessageId:   "2",
+				EventSource: "aws:sqs",
+				Body:        string(body2),
+			},
+			{
+				MessageId:   "3",
+				EventSource: "aws:sqs",
+				Body:        "not a json",
+			},
+		},
+	}
+
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, event))
+	assert.Nil(t, err)
+
+	response, ok := res.(awscont.BatchResponse)
+	assert.True(t, ok)
+	assert.Len(t, response.BatchItemFailures, 2)
+	assert.Equal(t, "2", response.BatchItemFailures[0].ItemIdentifier)
+	assert.Equal(t, "3", response.BatchItemFailures[1].ItemIdentifier)
+
+	// Check the valid message was processed
+	resBody, bodyErr := lambda.Act(map[string]interface{}{"cmd": "get_dummies"})
+	assert.Nil(t, bodyErr)
+
+	var dummies awstest.DummyDataPage
+	jsonErr := json.Unmarshal([]byte(resBody), &dummies)
+	assert.Nil(t, jsonErr)
+	assert.Len(t, dummies.Data, 1)
+}
@@ -0,0 +1,129 @@
+package test_container
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	awscont "github.com/pip-services3-go/pip-services3-aws-go/container"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaFunctionDynamoDBStreams(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	changes := make([]map[string]interface{}, 0)
+	lambda.RegisterAction("on_dummy_changed", nil, func(params map[string]interface{}) (interface{}, error) {
+		image, _ := params["new_image"].(map[string]interface{})
+		if image["key"] == "Bad" {
+			return nil, cerr.NewBadRequestError("", "BAD_KEY", "Bad key")
+		}
+		changes = append(changes, params)
+		return nil, nil
+	})
+	lambda.RegisterEventRoute("arn:aws:dynamodb:us-east-1:12342342332:table/dummies", "", "on_dummy_changed")
+
+	newRecord := func(sequenceNumber string, key string) events.DynamoDBEventRecord {
+		return events.DynamoDBEventRecord{
+			EventID:        sequenceNumber,
+			EventName:      "INSERT",
+			EventSource:    "aws:dynamodb",
+			EventSourceArn: "arn:aws:dynamodb:us-east-1:12342342332:table/dummies/stream/2020-01-01T00:00:00.000",
+			Change: events.DynamoDBStreamRecord{
+				SequenceNumber: sequenceNumber,
+				Keys: map[string]events.DynamoDBAttributeValue{
+					"id": events.NewStringAttribute(sequenceNumber),
+				},
+				NewImage: map[string]events.DynamoDBAttributeValue{
+					"id":      events.NewStringAttribute(sequenceNumber),
+					"key":     events.NewStringAttribute(key),
+					"version": events.NewNumberAttribute("2"),
+					"tags":    events.NewStringSetAttribute([]string{"a", "b"}),
+				},
+			},
+		}
+	}
+
+	event := events.DynamoDBEvent{
+		Records: []events.DynamoDBEventRecord{
+			newRecord("100", "Key 1"),
+			newRecord("101", "Bad"),
+			newRecord("102", "Key 3"),
+		},
+	}
+
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, event))
+	assert.Nil(t, err)
+
+	response, ok := res.(awscont.BatchResponse)
+	assert.True(t, ok)
+	assert.Len(t, response.BatchItemFailures, 1)
+	assert.Equal(t, "101", response.BatchItemFailures[0].ItemIdentifier)
+
+	assert.Len(t, changes, 1)
+	image := changes[0]["new_image"].(map[string]interface{})
+	assert.Equal(t, "Key 1", image["key"])
+	assert.Equal(t, int64(2), image["version"])
+	assert.Equal(t, []string{"a", "b"}, image["tags"])
+	assert.Equal(t, "INSERT", changes[0]["event_name"])
+}
+
+func TestDummyLambdaFunctionKinesis(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+
+	data1, _ := json.Marshal(map[string]interface{}{
+		"cmd":   "create_dummy",
+		"dummy": awstest.Dummy{Id: "", Key: "Key 1", Content: "Content 1"},
+	})
+	data2, _ := json.Marshal(map[string]interface{}{
+		"dummy": awstest.Dummy{Id: "", Key: "Key 2", Content: "Content 2"},
+	})
+
+	event := events.KinesisEvent{
+		Records: []events.KinesisEventRecord{
+			{
+				EventID:        "shardId-000:1",
+				EventSource:    "aws:kinesis",
+				EventSourceArn: "arn:aws:kinesis:us-east-1:12342342332:stream/dummies",
+				Kinesis:        events.KinesisRecord{Data: data1, SequenceNumber: "1"},
+			},
+			{
+				EventID:        "shardId-000:2",
+				EventSource:    "aws:kinesis",
+				EventSourceArn: "arn:aws:kinesis:us-east-1:12342342332:stream/dummies",
+				Kinesis:        events.KinesisRecord{Data: data2, SequenceNumber: "2"},
+			},
+		},
+	}
+
+	// Second record has no command and no route
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, event))
+	assert.Nil(t, err)
+
+	response := res.(awscont.BatchResponse)
+	assert.Len(t, response.BatchItemFailures, 1)
+	assert.Equal(t, "2", response.BatchItemFailures[0].ItemIdentifier)
+
+	// Route the stream and retry the failed record
+	lambda.RegisterEventRoute("arn:aws:kinesis:us-east-1:12342342332:stream/dummies", "", "create_dummy")
+	event.Records = event.Records[1:]
+
+	res, err = lambda.EventHandler(context.TODO(), toEventMap(t, event))
+	assert.Nil(t, err)
+
+	response = res.(awscont.BatchResponse)
+	assert.Len(t, response.BatchItemFailures, 0)
+
+	resBody, bodyErr := lambda.Act(map[string]interface{}{"cmd": "get_dummies"})
+	assert.Nil(t, bodyErr)
+
+	var dummies awstest.DummyDataPage
+	jsonErr := json.Unmarshal([]byte(resBody), &dummies)
+	assert.Nil(t, jsonErr)
+	assert.Len(t, dummies.Data, 2)
+}
@@ -0,0 +1,101 @@
+package test_container
+
+import (
+	"encoding/json"
+	"testing"
+
+	awsserv "github.com/pip-services3-go/pip-services3-aws-go/services"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLambdaOpenApiDocument(t *testing.T) {
+	lambda := openDummyLambdaFunction(t)
+	defer lambda.Close("")
+	lambda.RegisterRoute("GET", "/dummies", "get_dummies")
+
+	doc := awsserv.NewLambdaOpenApiDocument(cconf.NewConfigParamsFromTuples(
+		"name", "Dummies API",
+	), lambda.GetActions())
+
+	// JSON Schema is generated for every action
+	schemas := doc.GetJsonSchemas()
+	assert.Len(t, schemas, len(lambda.GetActions()))
+	schema := schemas["create_dummy"]
+	assert.Equal(t, "create_dummy", schema["title"])
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []string{"dummy"}, schema["required"])
+	dummy := schema["properties"].(map[string]interface{})["dummy"].(map[string]interface{})
+	assert.Equal(t, []string{"key"}, dummy["required"])
+	assert.Contains(t, dummy["properties"], "content")
+
+	content, err := doc.ToJson()
+	assert.Nil(t, err)
+
+	var data map[string]interface{}
+	err = json.Unmarshal([]byte(content), &data)
+	assert.Nil(t, err)
+	assert.Equal(t, "3.0.2", data["openapi"])
+	assert.Equal(t, "Dummies API", data["info"].(map[string]interface{})["title"])
+
+	paths := data["paths"].(map[string]interface{})
+	assert.Len(t, paths, 2)
+
+	create := paths["/dummies"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "create_dummy", create["operationId"])
+	assert.Equal(t, true, create["requestBody"].(map[string]interface{})["required"])
+	body := create["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	assert.Equal(t, "#/components/schemas/create_dummy", body["schema"].(map[string]interface{})["$ref"])
+
+	// Parameters of GET requests are passed in query
+	list := paths["/dummies"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.NotContains(t, list, "requestBody")
+	query := list["parameters"].([]interface{})
+	assert.Len(t, query, 2)
+	assert.Equal(t, "filter", query[0].(map[string]interface{})["name"])
+	assert.Equal(t, "paging", query[1].(map[string]interface{})["name"])
+	assert.Equal(t, "query", query[0].(map[string]interface{})["in"])
+	assert.Equal(t, false, query[0].(map[string]interface{})["required"])
+	assert.Equal(t, "object", query[1].(map[string]interface{})["schema"].(map[string]interface{})["type"])
+
+	get := paths["/dummies/{dummy_id}"].(map[string]interface{})["get"].(map[string]interface{})
+	parameters := get["parameters"].([]interface{})
+	assert.Len(t, parameters, 1)
+	assert.Equal(t, "dummy_id", parameters[0].(map[string]interface{})["name"])
+	assert.Equal(t, "path", parameters[0].(map[string]interface{})["in"])
+	assert.Contains(t, paths["/dummies/{dummy_id}"], "delete")
+
+	components := data["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Contains(t, components, "create_dummy")
+	createSchema := components["create_dummy"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"dummy"}, createSchema["required"])
+	assert.Contains(t, createSchema["properties"], "dummy")
+	assert.Contains(t, components, "ErrorDescription")
+}
+
+func TestCommandableLambdaFunctionJsonSchemas(t *testing.T) {
+	lambda := NewDummyCommandableLambdaFunction()
+	lambda.Configure(cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+	))
+	lambda.SetReferences(cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), awstest.NewDummyController(),
+	))
+	err := lambda.Open("")
+	assert.Nil(t, err)
+	defer lambda.Close("")
+
+	// Schemas are taken from controller commands
+	schemas := awsserv.NewLambdaOpenApiDocument(nil, lambda.GetActions()).GetJsonSchemas()
+	schema := schemas["get_dummy_by_id"]
+	assert.Equal(t, "object", schema["type"])
+	assert.Contains(t, schema["properties"], "dummy_id")
+
+	// Command schemas only describe actions, commands validate parameters themselves
+	for _, action := range lambda.GetActions() {
+		assert.Nil(t, action.Schema)
+		assert.NotNil(t, action.ParamsSchema)
+	}
+}
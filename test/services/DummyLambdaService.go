@@ -79,36 +79,55 @@ func (c *DummyLambdaService) deleteById(params map[string]interface{}) (interfac
 	)
 }
 
+func (c *DummyLambdaService) importDummy(params map[string]interface{}) (interface{}, error) {
+	correlationId, _ := params["correlation_id"].(string)
+	key, _ := params["key"].(string)
+	return c.controller.Create(
+		correlationId,
+		awstest.Dummy{Key: key, Content: "Imported"},
+	)
+}
+
 func (c *DummyLambdaService) Register() {
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"get_dummies",
-		&cvalid.NewObjectSchema().
+		cvalid.NewObjectSchema().
 			WithOptionalProperty("filter", cvalid.NewFilterParamsSchema()).
-			WithOptionalProperty("paging", cvalid.NewPagingParamsSchema()).Schema,
+			WithOptionalProperty("paging", cvalid.NewPagingParamsSchema()),
 		c.getPageByFilter)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"get_dummy_by_id",
-		&cvalid.NewObjectSchema().
-			WithOptionalProperty("dummy_id", cconv.String).Schema,
+		cvalid.NewObjectSchema().
+			WithOptionalProperty("dummy_id", cconv.String),
 		c.getOneById)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"create_dummy",
-		&cvalid.NewObjectSchema().
-			WithRequiredProperty("dummy", awstest.NewDummySchema()).Schema,
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy", awstest.NewDummySchema()),
 		c.create)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"update_dummy",
-		&cvalid.NewObjectSchema().
-			WithRequiredProperty("dummy", awstest.NewDummySchema()).Schema,
+		cvalid.NewObjectSchema().
+			WithRequiredProperty("dummy", awstest.NewDummySchema()),
 		c.update)
 
-	c.RegisterAction(
+	c.RegisterActionWithParamsSchema(
 		"delete_dummy",
-		&cvalid.NewObjectSchema().
-			WithOptionalProperty("dummy_id", cconv.String).Schema,
+		cvalid.NewObjectSchema().
+			WithOptionalProperty("dummy_id", cconv.String),
 		c.deleteById)
+
+	c.RegisterActionWithS3Events(
+		"import_dummy",
+		"pip-services-dummies",
+		"ObjectCreated:*",
+		"imports/",
+		".json",
+		&cvalid.NewObjectSchema().
+			WithRequiredProperty("key", cconv.String).Schema,
+		c.importDummy)
 }
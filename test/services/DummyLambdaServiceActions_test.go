@@ -0,0 +1,96 @@
+package test_services
+
+import (
+	"encoding/json"
+	"testing"
+
+	awscont "github.com/pip-services3-go/pip-services3-aws-go/container"
+	awsserv "github.com/pip-services3-go/pip-services3-aws-go/services"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cconv "github.com/pip-services3-go/pip-services3-commons-go/convert"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	cvalid "github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaServiceActions(t *testing.T) {
+	lambda := NewDummyLambdaFunction()
+	lambda.Configure(cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+		"service.descriptor", "pip-services-dummies:service:lambda:default:1.0",
+	))
+	lambda.SetReferences(cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), awstest.NewDummyController(),
+	))
+	err := lambda.Open("")
+	assert.Nil(t, err)
+	defer lambda.Close("")
+
+	actions := lambda.GetActions()
+	assert.True(t, len(actions) > 0)
+	for _, action := range actions {
+		assert.Equal(t, "dummy", action.Service)
+	}
+
+	resBody, err := lambda.Act(map[string]interface{}{"cmd": awscont.ActionsCommand})
+	assert.Nil(t, err)
+
+	var descriptions []map[string]interface{}
+	err = json.Unmarshal([]byte(resBody), &descriptions)
+	assert.Nil(t, err)
+	assert.Len(t, descriptions, len(actions))
+
+	var createDummy map[string]interface{}
+	for _, description := range descriptions {
+		if description["cmd"] == "dummy.create_dummy" {
+			createDummy = description
+		}
+	}
+	assert.NotNil(t, createDummy)
+	assert.Equal(t, "dummy", createDummy["service"])
+
+	schema := createDummy["schema"].(map[string]interface{})
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []interface{}{"dummy"}, schema["required"])
+	dummy := schema["properties"].(map[string]interface{})["dummy"].(map[string]interface{})
+	assert.Equal(t, "object", dummy["type"])
+	assert.Contains(t, dummy["properties"], "content")
+
+	// Reserved command cannot be registered
+	err = lambda.RegisterAction(awscont.ActionsCommand, nil, func(params map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestDescribeSchema(t *testing.T) {
+	schema := cvalid.NewObjectSchema().
+		WithRequiredProperty("id", cconv.String).
+		WithOptionalProperty("count", cconv.Integer).
+		WithOptionalProperty("tags", cvalid.NewArraySchema(cconv.String)).
+		WithOptionalProperty("time", cconv.DateTime).
+		WithOptionalProperty("dummy", awstest.NewDummySchema())
+
+	description := awsserv.DescribeSchema(schema)
+	assert.Equal(t, "object", description["type"])
+	assert.Equal(t, []string{"id"}, description["required"])
+
+	properties := description["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["id"])
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, properties["count"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}, properties["tags"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["time"])
+
+	// Custom schemas are described with properties of embedded ObjectSchema
+	dummy := properties["dummy"].(map[string]interface{})
+	assert.Equal(t, []string{"key"}, dummy["required"])
+	assert.Contains(t, dummy["properties"], "content")
+
+	// Base schemas are described as objects
+	assert.Equal(t, map[string]interface{}{"type": "object"}, awsserv.DescribeSchema(&schema.Schema))
+
+	assert.Nil(t, awsserv.DescribeSchema(nil))
+	assert.Nil(t, awsserv.DescribeSchema((*cvalid.Schema)(nil)))
+}
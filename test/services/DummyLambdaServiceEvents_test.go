@@ -0,0 +1,199 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cerr "github.com/pip-services3-go/pip-services3-commons-go/errors"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func toEventMap(t *testing.T, event interface{}) map[string]interface{} {
+	data, err := json.Marshal(event)
+	assert.Nil(t, err)
+	result := make(map[string]interface{})
+	err = json.Unmarshal(data, &result)
+	assert.Nil(t, err)
+	return result
+}
+
+func TestDummyLambdaServiceEvents(t *testing.T) {
+
+	restConfig := cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+		"service.descriptor", "pip-services-dummies:service:lambda:default:1.0",
+		"service.events.created.source", "arn:aws:sns:us-east-1:12342342332:dummies",
+		"service.events.created.cmd", "create_dummy",
+		"service.events.get_dummies.source", "nightly-report",
+		"service.events.changed.source", "dummies.source",
+		"service.events.changed.detail_type", "Dummy Changed",
+		"service.events.changed.cmd", "update_dummy",
+	)
+
+	lambda := NewDummyLambdaFunction()
+	lambda.Configure(restConfig)
+
+	var references *cref.References = cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), awstest.NewDummyController(),
+	)
+	lambda.SetReferences(references)
+	opnErr := lambda.Open("")
+	assert.Nil(t, opnErr)
+	defer lambda.Close("")
+
+	// Create dummy from SNS notification
+	message, _ := json.Marshal(map[string]interface{}{
+		"dummy": awstest.Dummy{Id: "", Key: "Key 1", Content: "Content 1"},
+	})
+	snsEvent := events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{
+				EventSource: "aws:sns",
+				SNS: events.SNSEntity{
+					MessageID: "1",
+					TopicArn:  "arn:aws:sns:us-east-1:12342342332:dummies",
+					Message:   string(message),
+				},
+			},
+		},
+	}
+
+	_, err := lambda.EventHandler(context.TODO(), toEventMap(t, snsEvent))
+	assert.Nil(t, err)
+
+	// Call action on scheduled event
+	scheduledEvent := events.CloudWatchEvent{
+		ID:         "2",
+		DetailType: "Scheduled Event",
+		Source:     "aws.events",
+		Time:       time.Now(),
+		Resources:  []string{"arn:aws:events:us-east-1:12342342332:rule/nightly-report"},
+		Detail:     json.RawMessage("{}"),
+	}
+
+	res, err := lambda.EventHandler(context.TODO(), toEventMap(t, scheduledEvent))
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	resBody, bodyErr := lambda.Act(map[string]interface{}{"cmd": "dummy.get_dummies"})
+	assert.Nil(t, bodyErr)
+
+	var dummies awstest.DummyDataPage
+	jsonErr := json.Unmarshal([]byte(resBody), &dummies)
+	assert.Nil(t, jsonErr)
+	assert.Len(t, dummies.Data, 1)
+
+	// Update dummy from EventBridge event
+	dummy := dummies.Data[0]
+	dummy.Content = "Updated Content 1"
+	detail, _ := json.Marshal(map[string]interface{}{"dummy": dummy})
+
+	ebEvent := events.CloudWatchEvent{
+		ID:         "3",
+		DetailType: "Dummy Changed",
+		Source:     "dummies.source",
+		Time:       time.Now(),
+		Detail:     detail,
+	}
+
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, ebEvent))
+	assert.Nil(t, err)
+
+	resBody, bodyErr = lambda.Act(map[string]interface{}{"cmd": "dummy.get_dummy_by_id", "dummy_id": dummy.Id})
+	assert.Nil(t, bodyErr)
+
+	var dummy1 awstest.Dummy
+	jsonErr = json.Unmarshal([]byte(resBody), &dummy1)
+	assert.Nil(t, jsonErr)
+	assert.Equal(t, "Updated Content 1", dummy1.Content)
+
+	// Fail on unknown event
+	ebEvent.DetailType = "Dummy Deleted"
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, ebEvent))
+	assert.NotNil(t, err)
+
+	// SNS routes do not match EventBridge events with the topic in resources
+	ebEvent = events.CloudWatchEvent{
+		ID:         "4",
+		DetailType: "Topic Changed",
+		Source:     "aws.sns",
+		Time:       time.Now(),
+		Resources:  []string{"arn:aws:sns:us-east-1:12342342332:dummies"},
+		Detail:     json.RawMessage("{}"),
+	}
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, ebEvent))
+	assert.NotNil(t, err)
+	assert.Equal(t, "NO_EVENT_ROUTE", err.(*cerr.ApplicationError).Code)
+}
+
+func TestDummyLambdaServiceEventSource(t *testing.T) {
+
+	restConfig := cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+		"service.descriptor", "pip-services-dummies:service:lambda:default:1.0",
+		"service.events.created.source", "*",
+		"service.events.created.event_source", "aws:kinesis",
+		"service.events.created.cmd", "create_dummy",
+	)
+
+	lambda := NewDummyLambdaFunction()
+	lambda.Configure(restConfig)
+
+	var references *cref.References = cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), awstest.NewDummyController(),
+	)
+	lambda.SetReferences(references)
+	opnErr := lambda.Open("")
+	assert.Nil(t, opnErr)
+	defer lambda.Close("")
+
+	message, _ := json.Marshal(map[string]interface{}{
+		"dummy": awstest.Dummy{Id: "", Key: "Key 1", Content: "Content 1"},
+	})
+
+	// Route with any source is scoped by the configured type of event source
+	snsEvent := events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{
+				EventSource: "aws:sns",
+				SNS: events.SNSEntity{
+					MessageID: "1",
+					TopicArn:  "arn:aws:sns:us-east-1:12342342332:dummies",
+					Message:   string(message),
+				},
+			},
+		},
+	}
+	_, err := lambda.EventHandler(context.TODO(), toEventMap(t, snsEvent))
+	assert.NotNil(t, err)
+	assert.Equal(t, "NO_EVENT_ROUTE", err.(*cerr.ApplicationError).Code)
+
+	kinesisEvent := events.KinesisEvent{
+		Records: []events.KinesisEventRecord{
+			{
+				EventID:        "shardId-000:1",
+				EventSource:    "aws:kinesis",
+				EventSourceArn: "arn:aws:kinesis:us-east-1:12342342332:stream/dummies",
+				Kinesis:        events.KinesisRecord{Data: message, SequenceNumber: "1"},
+			},
+		},
+	}
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, kinesisEvent))
+	assert.Nil(t, err)
+
+	resBody, bodyErr := lambda.Act(map[string]interface{}{"cmd": "dummy.get_dummies"})
+	assert.Nil(t, bodyErr)
+
+	var dummies awstest.DummyDataPage
+	jsonErr := json.Unmarshal([]byte(resBody), &dummies)
+	assert.Nil(t, jsonErr)
+	assert.Len(t, dummies.Data, 1)
+}
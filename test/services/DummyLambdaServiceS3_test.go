@@ -0,0 +1,80 @@
+package test_services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	awstest "github.com/pip-services3-go/pip-services3-aws-go/test"
+	cconf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	cref "github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDummyLambdaServiceS3(t *testing.T) {
+
+	restConfig := cconf.NewConfigParamsFromTuples(
+		"logger.descriptor", "pip-services:logger:console:default:1.0",
+		"controller.descriptor", "pip-services-dummies:controller:default:default:1.0",
+		"service.descriptor", "pip-services-dummies:service:lambda:default:1.0",
+	)
+
+	lambda := NewDummyLambdaFunction()
+	lambda.Configure(restConfig)
+
+	var references *cref.References = cref.NewReferencesFromTuples(
+		cref.NewDescriptor("pip-services-dummies", "controller", "default", "default", "1.0"), awstest.NewDummyController(),
+	)
+	lambda.SetReferences(references)
+	opnErr := lambda.Open("")
+	assert.Nil(t, opnErr)
+	defer lambda.Close("")
+
+	newRecord := func(eventName string, key string) events.S3EventRecord {
+		record := events.S3EventRecord{
+			EventSource: "aws:s3",
+			EventName:   eventName,
+		}
+		record.S3.Bucket.Name = "pip-services-dummies"
+		record.S3.Bucket.Arn = "arn:aws:s3:::pip-services-dummies"
+		record.S3.Object.Key = key
+		return record
+	}
+
+	// Import uploaded object
+	event := events.S3Event{
+		Records: []events.S3EventRecord{
+			newRecord("ObjectCreated:Put", "imports/dummy+1.json"),
+		},
+	}
+
+	_, err := lambda.EventHandler(context.TODO(), toEventMap(t, event))
+	assert.Nil(t, err)
+
+	resBody, bodyErr := lambda.Act(map[string]interface{}{"cmd": "dummy.get_dummies"})
+	assert.Nil(t, bodyErr)
+
+	var dummies awstest.DummyDataPage
+	jsonErr := json.Unmarshal([]byte(resBody), &dummies)
+	assert.Nil(t, jsonErr)
+	assert.Len(t, dummies.Data, 1)
+	assert.Equal(t, "imports/dummy 1.json", dummies.Data[0].Key)
+
+	// Skip objects outside of the prefix and removed objects
+	event = events.S3Event{
+		Records: []events.S3EventRecord{
+			newRecord("ObjectCreated:Put", "exports/dummy2.json"),
+			newRecord("ObjectRemoved:Delete", "imports/dummy1.json"),
+		},
+	}
+
+	_, err = lambda.EventHandler(context.TODO(), toEventMap(t, event))
+	assert.NotNil(t, err)
+
+	resBody, bodyErr = lambda.Act(map[string]interface{}{"cmd": "dummy.get_dummies"})
+	assert.Nil(t, bodyErr)
+	jsonErr = json.Unmarshal([]byte(resBody), &dummies)
+	assert.Nil(t, jsonErr)
+	assert.Len(t, dummies.Data, 1)
+}
@@ -0,0 +1,64 @@
+package test_services
+
+import (
+	"context"
+	"testing"
+
+	awsserv "github.com/pip-services3-go/pip-services3-aws-go/services"
+	cconv "github.com/pip-services3-go/pip-services3-commons-go/convert"
+	cvalid "github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey string
+
+type contextLambdaService struct {
+	*awsserv.LambdaService
+	intercepted int
+}
+
+func (c *contextLambdaService) Register() {
+	c.RegisterInterceptor(func(params map[string]interface{}, next func(params map[string]interface{}) (interface{}, error)) (interface{}, error) {
+		c.intercepted++
+		return next(params)
+	})
+
+	c.RegisterActionWithContext("get_value",
+		&cvalid.NewObjectSchema().WithRequiredProperty("key", cconv.String).Schema,
+		func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return ctx.Value(contextKey(params["key"].(string))), nil
+		})
+}
+
+func TestLambdaServiceActionWithContext(t *testing.T) {
+	service := &contextLambdaService{}
+	service.LambdaService = awsserv.InheritLambdaService(service, "context")
+	err := service.Open("")
+	assert.Nil(t, err)
+	defer service.Close("")
+
+	actions := service.GetActions()
+	assert.Len(t, actions, 1)
+	action := actions[0]
+
+	ctx := context.WithValue(context.Background(), contextKey("key1"), "value1")
+	res, err := action.ActionWithContext(ctx, map[string]interface{}{"key": "key1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "value1", res)
+	assert.Equal(t, 1, service.intercepted)
+
+	// Each call gets its own context
+	res, err = action.ActionWithContext(context.Background(), map[string]interface{}{"key": "key1"})
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	assert.Equal(t, 2, service.intercepted)
+
+	// Validation runs inside interceptors
+	_, err = action.ActionWithContext(ctx, map[string]interface{}{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, service.intercepted)
+
+	res, err = action.Action(map[string]interface{}{"key": "key1"})
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}